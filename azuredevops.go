@@ -0,0 +1,267 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// azure devops version query prefixes
+// GB<branch>, GT<tag>, GC<commit>
+var azureDevOpsVersionPrefixes = map[string]string{
+	"GB": RefBranch,
+	"GT": RefTag,
+	"GC": RefCommit,
+}
+
+// azure devops provider, dev.azure.com and old visualstudio.com organizations
+type azureDevOpsProvider struct {
+	GenericProvider
+}
+
+func (azureDevOpsProvider) Forge() string {
+	return ForgeAzureDevOps
+}
+
+// https://<organization>.visualstudio.com old azure devops urls
+func (azureDevOpsProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "dev.azure.com", "ssh.dev.azure.com") || strings.HasSuffix(strings.ToLower(u.Hostname()), ".visualstudio.com")
+}
+
+func (azureDevOpsProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseAzureDevOpsRoute(u, filename)
+}
+
+// https://[HOSTNAME]/[ORGANIZATION]/[PROJECT]/_git/[NAME]
+func (azureDevOpsProvider) BaseUrl(l *Location) string {
+	return l.getAzureDevOpsProjectUrl() + "/_git/" + l.Name
+}
+
+// organizations, projects and names are case insensitive, raw path is the repository path
+func (azureDevOpsProvider) foldCase(l *Location) {
+	l.lowerOwnerName()
+	l.RawPath = strings.ToLower(l.RawPath)
+}
+
+// path and version live in query string
+func (azureDevOpsProvider) BrowseUrl(l *Location) string {
+	return l.getAzureDevOpsBrowseUrl(l.Path)
+}
+
+// browse urls of files without extension parse back as folders
+// files are contents views, folders end with slash
+func (azureDevOpsProvider) canonicalUrl(l *Location) string {
+	switch {
+	case l.Path == "":
+		return l.getAzureDevOpsBrowseUrl("")
+	case l.IsFile:
+		return l.getAzureDevOpsBrowseUrl(l.Path) + "&_a=contents"
+	}
+
+	return l.getAzureDevOpsBrowseUrl(l.Path + "/")
+}
+
+// https://dev.azure.com/[ORGANIZATION]/[PROJECT]/_git/[NAME]
+func (azureDevOpsProvider) CloneUrl(l *Location) string {
+	return l.getBaseUrl()
+}
+
+// git@ssh.dev.azure.com:v3/[ORGANIZATION]/[PROJECT]/[NAME]
+// [ORGANIZATION]@vs-ssh.visualstudio.com:v3/[ORGANIZATION]/[PROJECT]/[NAME]
+func (azureDevOpsProvider) RemoteUrl(l *Location) string {
+	organization, _, _ := strings.Cut(l.Owner, "/")
+	if l.Hostname == "dev.azure.com" {
+		return "git@ssh.dev.azure.com:v3/" + l.Owner + "/" + l.Name
+	}
+	return organization + "@vs-ssh.visualstudio.com:v3/" + l.Owner + "/" + l.Name
+}
+
+// https://dev.azure.com/[ORGANIZATION]/[PROJECT]/_apis/git/repositories/[NAME]/items?%24format=zip&download=true&path=%2F&versionDescriptor.version=[BRANCH]&versionDescriptor.versionType=[KIND]
+func (azureDevOpsProvider) ArchiveUrl(l *Location) string {
+	return l.getAzureDevOpsItemsUrl("/", "zip")
+}
+
+// https://dev.azure.com/[ORGANIZATION]/[PROJECT]/_apis/git/repositories/[NAME]/items?download=true&path=%2F[PATH]&versionDescriptor.version=[BRANCH]&versionDescriptor.versionType=[KIND]
+// [PATH] placeholder stays unescaped
+func (azureDevOpsProvider) FileUrl(l *Location, path string) string {
+	return strings.ReplaceAll(l.getAzureDevOpsItemsUrl("/"+path, ""), url.QueryEscape("[PATH]"), "[PATH]")
+}
+
+// https://dev.azure.com/[ORGANIZATION]/[PROJECT]/_git/[NAME]?path=/[PATH]&version=GB[BRANCH]
+func (azureDevOpsProvider) QueryUrl(l *Location, path string) string {
+	return l.getAzureDevOpsBrowseUrl(path)
+}
+
+// parse azure devops routes
+/*
+https://dev.azure.com/<organization>/<project>/_git/<repo>?path=/<path>&version=GB<branch>
+https://dev.azure.com/<organization>/_git/<repo> -> project name is the same of repo name
+https://dev.azure.com/<organization>/<project>/_apis/git/repositories/<repo>/items?path=/<path>&versionDescriptor.version=<branch> -> items api
+https://<organization>.visualstudio.com/[DefaultCollection/]<project>/_git/<repo>
+ssh://git@ssh.dev.azure.com/v3/<organization>/<project>/<repo>
+ssh://<organization>@vs-ssh.visualstudio.com/v3/<organization>/<project>/<repo>
+*/
+func (l *Location) parseAzureDevOpsRoute(u *url.URL, filename string) error {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var organization, project string
+	items := false
+	if l.Protocol == "ssh" {
+		// v3/<organization>/<project>/<repo>
+		switch {
+		case segments[0] != "v3":
+			return newParseError(ErrUnknownRoute, segments[0])
+		case len(segments) < 4:
+			return newParseError(ErrMissingRepo, "")
+		case len(segments) > 4:
+			return newParseError(ErrUnknownRoute, segments[4])
+		}
+		organization, project, l.Name = segments[1], segments[2], segments[3]
+
+		// ssh hostnames are not web hostnames
+		if l.Hostname == "ssh.dev.azure.com" {
+			l.Hostname = "dev.azure.com"
+		} else {
+			l.Hostname = organization + ".visualstudio.com"
+		}
+	} else {
+		if l.Hostname == "dev.azure.com" {
+			organization, segments = segments[0], segments[1:]
+		} else {
+			organization = strings.TrimSuffix(l.Hostname, ".visualstudio.com")
+			if len(segments) > 0 && segments[0] == "DefaultCollection" {
+				segments = segments[1:]
+			}
+		}
+
+		// [<project>/]_git/<repo>, <project>/_apis/git/repositories/<repo>/items
+		switch {
+		case len(segments) == 6 && segments[1] == "_apis" && segments[2] == "git" && segments[3] == "repositories" && segments[5] == "items":
+			project, l.Name, items = segments[0], segments[4], true
+		case len(segments) == 3 && segments[1] == "_git":
+			project, l.Name = segments[0], segments[2]
+		case len(segments) == 2 && segments[0] == "_git":
+			project, l.Name = segments[1], segments[1]
+		case len(segments) > 3 && segments[1] == "_git":
+			return newParseError(ErrUnknownRoute, segments[3])
+		default:
+			return newParseError(ErrMissingRepo, "")
+		}
+	}
+
+	switch {
+	case organization == "" || project == "":
+		return newParseError(ErrMissingOwner, "")
+	case l.Name == "":
+		return newParseError(ErrMissingRepo, "")
+	}
+
+	l.Owner = organization + "/" + project
+	l.RawPath = "/" + project + "/_git/" + l.Name
+	if l.Hostname == "dev.azure.com" {
+		l.RawPath = "/" + organization + l.RawPath
+	}
+
+	// version query: GB<branch>, GT<tag>, GC<commit>
+	// items api: versionDescriptor.version and versionDescriptor.versionType
+	query := u.Query()
+	if version := query.Get("versionDescriptor.version"); items && version != "" {
+		l.Branch, l.RefKind = version, RefBranch
+		if kind := query.Get("versionDescriptor.versionType"); kind == RefTag || kind == RefCommit {
+			l.RefKind = kind
+		}
+	} else if version := query.Get("version"); version != "" {
+		if len(version) <= 2 {
+			return newParseError(ErrInvalidRef, version)
+		}
+		kind, ok := azureDevOpsVersionPrefixes[version[:2]]
+		if !ok {
+			return newParseError(ErrInvalidRef, version)
+		}
+		l.Branch = version[2:]
+		l.RefKind = kind
+	}
+
+	// path query: folder or file path
+	path := query.Get("path")
+	l.Path = strings.Trim(filepath.Join(path, filename), "/")
+
+	// route evidence first: filename, items api (zip format is a folder), trailing slash of path,
+	// file views (_a=contents, line selection)
+	// fallback: web urls do not tell file or folder, file names have an extension
+	switch {
+	case filename != "":
+		l.IsFile = true
+	case l.Path == "" || strings.HasSuffix(path, "/"):
+		l.IsFile = false
+	case items:
+		l.IsFile = query.Get("$format") != "zip"
+	case query.Get("_a") == "contents" || query.Has("line"):
+		l.IsFile = true
+	default:
+		l.IsFile = filepath.Ext(l.Path) != ""
+	}
+
+	return nil
+}
+
+// generate azure devops project url
+// https://dev.azure.com/[ORGANIZATION]/[PROJECT] or https://[ORGANIZATION].visualstudio.com/[PROJECT]
+func (l *Location) getAzureDevOpsProjectUrl() string {
+	if l.Hostname == "dev.azure.com" {
+		return l.Scheme + "://" + l.Hostname + "/" + l.Owner
+	}
+
+	_, project, _ := strings.Cut(l.Owner, "/")
+	return l.Scheme + "://" + l.Hostname + "/" + project
+}
+
+// generate azure devops version query value
+func (l *Location) getAzureDevOpsVersion() string {
+	for prefix, kind := range azureDevOpsVersionPrefixes {
+		if kind == l.RefKind {
+			return prefix + l.Branch
+		}
+	}
+
+	return "GB" + l.Branch
+}
+
+// generate azure devops web url
+// https://dev.azure.com/[ORGANIZATION]/[PROJECT]/_git/[NAME]?path=/[PATH]&version=GB[BRANCH]
+func (l *Location) getAzureDevOpsBrowseUrl(path string) string {
+	query := []string{}
+	if path != "" {
+		query = append(query, "path=/"+escapeQueryPath(path))
+	}
+	if l.Branch != "" {
+		query = append(query, "version="+escapeQueryPath(l.getAzureDevOpsVersion()))
+	}
+
+	if len(query) == 0 {
+		return l.getBaseUrl()
+	}
+
+	return l.getBaseUrl() + "?" + strings.Join(query, "&")
+}
+
+// generate azure devops items api url, query values escaped
+// https://dev.azure.com/[ORGANIZATION]/[PROJECT]/_apis/git/repositories/[NAME]/items?%24format=[FORMAT]&download=true&path=[PATH]&versionDescriptor.version=[BRANCH]&versionDescriptor.versionType=[KIND]
+func (l *Location) getAzureDevOpsItemsUrl(path, format string) string {
+	query := url.Values{}
+	query.Set("path", path)
+	query.Set("download", "true")
+	if format != "" {
+		query.Set("$format", format)
+	}
+	if l.Branch != "" {
+		kind := l.RefKind
+		if kind == "" {
+			kind = RefBranch
+		}
+		query.Set("versionDescriptor.versionType", kind)
+		query.Set("versionDescriptor.version", l.Branch)
+	}
+
+	return l.getAzureDevOpsProjectUrl() + "/_apis/git/repositories/" + l.Name + "/items?" + query.Encode()
+}
@@ -0,0 +1,459 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_AzureDevOpsParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Azure DevOps Repository",
+			url:    "https://dev.azure.com/org/project/_git/repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://dev.azure.com/org/project/_git/repo",
+				RawUrl:       "https://dev.azure.com/org/project/_git/repo",
+				CloneUrl:     "https://dev.azure.com/org/project/_git/repo",
+				RemoteUrl:    "git@ssh.dev.azure.com:v3/org/project/repo",
+				QueryUrl:     "https://dev.azure.com/org/project/_git/repo",
+				DirPath:      keyDirPath(Key{Hostname: "dev.azure.com", Namespace: "org/project", Name: "repo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "dev.azure.com",
+				Forge:        ForgeAzureDevOps,
+				RawPath:      "/org/project/_git/repo",
+				Path:         "",
+				Owner:        "org/project",
+				Name:         "repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?%24format=zip&download=true&path=%2F",
+				FileUrl:      "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?download=true&path=%2F[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Azure DevOps Repository Some Folder",
+			url:    "https://dev.azure.com/org/project/_git/repo?path=/src/app&version=GBmain",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://dev.azure.com/org/project/_git/repo?path=/src/app&version=GBmain",
+				RawUrl:       "https://dev.azure.com/org/project/_git/repo?path=/src/app&version=GBmain",
+				CloneUrl:     "https://dev.azure.com/org/project/_git/repo",
+				RemoteUrl:    "git@ssh.dev.azure.com:v3/org/project/repo",
+				QueryUrl:     "https://dev.azure.com/org/project/_git/repo?path=/src/app&version=GBmain",
+				DirPath:      keyDirPath(Key{Hostname: "dev.azure.com", Namespace: "org/project", Name: "repo", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "dev.azure.com",
+				Forge:        ForgeAzureDevOps,
+				RawPath:      "/org/project/_git/repo",
+				Path:         "src/app",
+				Owner:        "org/project",
+				Name:         "repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?%24format=zip&download=true&path=%2F&versionDescriptor.version=main&versionDescriptor.versionType=branch",
+				FileUrl:      "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?download=true&path=%2F[PATH]&versionDescriptor.version=main&versionDescriptor.versionType=branch",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Azure DevOps Repository Tag Single File",
+			url:    "https://org@dev.azure.com/org/project/_git/repo?path=/src/app/main.go&version=GTv1.0.0",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://dev.azure.com/org/project/_git/repo?path=/src/app/main.go&version=GTv1.0.0",
+				RawUrl:       "https://org@dev.azure.com/org/project/_git/repo?path=/src/app/main.go&version=GTv1.0.0",
+				CloneUrl:     "https://dev.azure.com/org/project/_git/repo",
+				RemoteUrl:    "git@ssh.dev.azure.com:v3/org/project/repo",
+				QueryUrl:     "https://dev.azure.com/org/project/_git/repo?path=/src/app&version=GTv1.0.0",
+				DirPath:      keyDirPath(Key{Hostname: "dev.azure.com", Namespace: "org/project", Name: "repo", RefKind: RefTag, Ref: "v1.0.0"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "dev.azure.com",
+				Forge:        ForgeAzureDevOps,
+				RawPath:      "/org/project/_git/repo",
+				Path:         "src/app/main.go",
+				Owner:        "org/project",
+				Name:         "repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v1.0.0",
+				RefKind:      RefTag,
+				ArchiveUrl:   "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?%24format=zip&download=true&path=%2F&versionDescriptor.version=v1.0.0&versionDescriptor.versionType=tag",
+				FileUrl:      "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?download=true&path=%2F[PATH]&versionDescriptor.version=v1.0.0&versionDescriptor.versionType=tag",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Azure DevOps Slashes Branch Name Folder",
+			url:    "https://dev.azure.com/org/project/_git/repo?path=/docs&version=GBfeature/login",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://dev.azure.com/org/project/_git/repo?path=/docs&version=GBfeature/login",
+				RawUrl:       "https://dev.azure.com/org/project/_git/repo?path=/docs&version=GBfeature/login",
+				CloneUrl:     "https://dev.azure.com/org/project/_git/repo",
+				RemoteUrl:    "git@ssh.dev.azure.com:v3/org/project/repo",
+				QueryUrl:     "https://dev.azure.com/org/project/_git/repo?path=/docs&version=GBfeature/login",
+				DirPath:      keyDirPath(Key{Hostname: "dev.azure.com", Namespace: "org/project", Name: "repo", RefKind: RefBranch, Ref: "feature/login"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "dev.azure.com",
+				Forge:        ForgeAzureDevOps,
+				RawPath:      "/org/project/_git/repo",
+				Path:         "docs",
+				Owner:        "org/project",
+				Name:         "repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "feature/login",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?%24format=zip&download=true&path=%2F&versionDescriptor.version=feature%2Flogin&versionDescriptor.versionType=branch",
+				FileUrl:      "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?download=true&path=%2F[PATH]&versionDescriptor.version=feature%2Flogin&versionDescriptor.versionType=branch",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Azure DevOps Project Default Repository",
+			url:    "https://dev.azure.com/org/_git/repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://dev.azure.com/org/repo/_git/repo",
+				RawUrl:       "https://dev.azure.com/org/_git/repo",
+				CloneUrl:     "https://dev.azure.com/org/repo/_git/repo",
+				RemoteUrl:    "git@ssh.dev.azure.com:v3/org/repo/repo",
+				QueryUrl:     "https://dev.azure.com/org/repo/_git/repo",
+				DirPath:      keyDirPath(Key{Hostname: "dev.azure.com", Namespace: "org/repo", Name: "repo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "dev.azure.com",
+				Forge:        ForgeAzureDevOps,
+				RawPath:      "/org/repo/_git/repo",
+				Path:         "",
+				Owner:        "org/repo",
+				Name:         "repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://dev.azure.com/org/repo/_apis/git/repositories/repo/items?%24format=zip&download=true&path=%2F",
+				FileUrl:      "https://dev.azure.com/org/repo/_apis/git/repositories/repo/items?download=true&path=%2F[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Azure DevOps Visualstudio Commit Repository",
+			url:    "https://org.visualstudio.com/DefaultCollection/project/_git/repo?version=GCabc123",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://org.visualstudio.com/project/_git/repo?version=GCabc123",
+				RawUrl:       "https://org.visualstudio.com/DefaultCollection/project/_git/repo?version=GCabc123",
+				CloneUrl:     "https://org.visualstudio.com/project/_git/repo",
+				RemoteUrl:    "org@vs-ssh.visualstudio.com:v3/org/project/repo",
+				QueryUrl:     "https://org.visualstudio.com/project/_git/repo?version=GCabc123",
+				DirPath:      keyDirPath(Key{Hostname: "org.visualstudio.com", Namespace: "org/project", Name: "repo", RefKind: RefCommit, Ref: "abc123"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "org.visualstudio.com",
+				Forge:        ForgeAzureDevOps,
+				RawPath:      "/project/_git/repo",
+				Path:         "",
+				Owner:        "org/project",
+				Name:         "repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "abc123",
+				RefKind:      RefCommit,
+				ArchiveUrl:   "https://org.visualstudio.com/project/_apis/git/repositories/repo/items?%24format=zip&download=true&path=%2F&versionDescriptor.version=abc123&versionDescriptor.versionType=commit",
+				FileUrl:      "https://org.visualstudio.com/project/_apis/git/repositories/repo/items?download=true&path=%2F[PATH]&versionDescriptor.version=abc123&versionDescriptor.versionType=commit",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Azure DevOps SSH Repository",
+			url:    "git@ssh.dev.azure.com:v3/org/project/repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://dev.azure.com/org/project/_git/repo",
+				RawUrl:       "git@ssh.dev.azure.com:v3/org/project/repo",
+				CloneUrl:     "https://dev.azure.com/org/project/_git/repo",
+				RemoteUrl:    "git@ssh.dev.azure.com:v3/org/project/repo",
+				QueryUrl:     "https://dev.azure.com/org/project/_git/repo",
+				DirPath:      keyDirPath(Key{Hostname: "dev.azure.com", Namespace: "org/project", Name: "repo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "dev.azure.com",
+				Forge:        ForgeAzureDevOps,
+				RawPath:      "/org/project/_git/repo",
+				Path:         "",
+				Owner:        "org/project",
+				Name:         "repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?%24format=zip&download=true&path=%2F",
+				FileUrl:      "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?download=true&path=%2F[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Azure DevOps Visualstudio SSH Repository",
+			url:    "org@vs-ssh.visualstudio.com:v3/org/project/repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://org.visualstudio.com/project/_git/repo",
+				RawUrl:       "org@vs-ssh.visualstudio.com:v3/org/project/repo",
+				CloneUrl:     "https://org.visualstudio.com/project/_git/repo",
+				RemoteUrl:    "org@vs-ssh.visualstudio.com:v3/org/project/repo",
+				QueryUrl:     "https://org.visualstudio.com/project/_git/repo",
+				DirPath:      keyDirPath(Key{Hostname: "org.visualstudio.com", Namespace: "org/project", Name: "repo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "org.visualstudio.com",
+				Forge:        ForgeAzureDevOps,
+				RawPath:      "/project/_git/repo",
+				Path:         "",
+				Owner:        "org/project",
+				Name:         "repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://org.visualstudio.com/project/_apis/git/repositories/repo/items?%24format=zip&download=true&path=%2F",
+				FileUrl:      "https://org.visualstudio.com/project/_apis/git/repositories/repo/items?download=true&path=%2F[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Azure DevOps Not Valid Route",
+			url:    "https://dev.azure.com/org/project/repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://dev.azure.com/org/project/repo",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "dev.azure.com",
+				Forge:       ForgeAzureDevOps,
+				Path:        "",
+				Owner:       "",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Parse Azure DevOps Not Valid Version",
+			url:    "https://dev.azure.com/org/project/_git/repo?version=XXmain",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://dev.azure.com/org/project/_git/repo?version=XXmain",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "dev.azure.com",
+				Forge:       ForgeAzureDevOps,
+				RawPath:     "/org/project/_git/repo",
+				Path:        "",
+				Owner:       "org/project",
+				Name:        "repo",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+func TestGitRepository_AzureDevOpsItems(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		filename   string
+		wantBranch string
+		wantPath   string
+		wantIsFile bool
+	}{
+		{name: "Contents View", url: "https://dev.azure.com/org/project/_git/repo?path=/Makefile&version=GTv1&_a=contents", wantBranch: "v1", wantPath: "Makefile", wantIsFile: true},
+		{name: "Line Selection", url: "https://dev.azure.com/org/project/_git/repo?path=/bin/setup&version=GBmain&line=3", wantBranch: "main", wantPath: "bin/setup", wantIsFile: true},
+		{name: "Dotted Folder", url: "https://dev.azure.com/org/project/_git/repo?path=/v1.2/&version=GBmain", wantBranch: "main", wantPath: "v1.2", wantIsFile: false},
+		{name: "Items File", url: "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?path=/Makefile&versionDescriptor.versionType=tag&versionDescriptor.version=v1&download=true", wantBranch: "v1", wantPath: "Makefile", wantIsFile: true},
+		{name: "Items Folder Archive", url: "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?path=/docs&versionDescriptor.version=main&download=true&$format=zip", wantBranch: "main", wantPath: "docs", wantIsFile: false},
+		{name: "Filename", url: "https://dev.azure.com/org/project/_git/repo?path=/bin&version=GBmain", filename: "setup", wantBranch: "main", wantPath: "bin/setup", wantIsFile: true},
+		{name: "Extension Fallback", url: "https://dev.azure.com/org/project/_git/repo?path=/go.mod&version=GBmain", wantBranch: "main", wantPath: "go.mod", wantIsFile: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGitRepository("", "", tt.url, "")
+			if err := r.Parse("", DirectionNone, tt.filename); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+			if r.Branch != tt.wantBranch || r.Path != tt.wantPath || r.IsFile != tt.wantIsFile {
+				t.Errorf("GitRepository.Parse() Branch = %q, Path = %q, IsFile = %v, want %q, %q, %v", r.Branch, r.Path, r.IsFile, tt.wantBranch, tt.wantPath, tt.wantIsFile)
+			}
+		})
+	}
+}
+
+func TestGitRepository_AzureDevOpsItemsEscaped(t *testing.T) {
+	r := NewGitRepository("", "", "https://dev.azure.com/org/project/_git/repo?path=/a%20b%26c.txt&version=GBfeat%26x%23y", "")
+	if err := r.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+
+	wantArchive := "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?%24format=zip&download=true&path=%2F&versionDescriptor.version=feat%26x%23y&versionDescriptor.versionType=branch"
+	if r.ArchiveUrl != wantArchive {
+		t.Errorf("ArchiveUrl = %v, want %v", r.ArchiveUrl, wantArchive)
+	}
+	wantFile := "https://dev.azure.com/org/project/_apis/git/repositories/repo/items?download=true&path=%2Fa+b%26c.txt&versionDescriptor.version=feat%26x%23y&versionDescriptor.versionType=branch"
+	if got := (azureDevOpsProvider{}).FileUrl(r.location(), r.Path); got != wantFile {
+		t.Errorf("FileUrl(%q) = %v, want %v", r.Path, got, wantFile)
+	}
+
+	items := NewGitRepository("", "", wantFile, "")
+	if err := items.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse(%q) error = %v", wantFile, err)
+	}
+	if items.Branch != r.Branch || items.Path != r.Path || !items.IsFile {
+		t.Errorf("GitRepository.Parse(%q) Branch = %q, Path = %q, IsFile = %v", wantFile, items.Branch, items.Path, items.IsFile)
+	}
+}
+
+func TestGitRepository_AzureDevOpsSubFolder(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		sub          string
+		direction    Direction
+		wantPath     string
+		wantUrl      string
+		wantCloneUrl string
+	}{
+		{
+			name:         "Azure DevOps Sub Folder Down",
+			url:          "https://dev.azure.com/org/project/_git/repo?path=/docs&version=GBmain",
+			sub:          "api",
+			direction:    DirectionDown,
+			wantPath:     "docs/api",
+			wantUrl:      "https://dev.azure.com/org/project/_git/repo?path=/docs/api&version=GBmain",
+			wantCloneUrl: "https://dev.azure.com/org/project/_git/repo",
+		},
+		{
+			name:         "Azure DevOps Sub Folder Up",
+			url:          "https://dev.azure.com/org/project/_git/repo?path=/docs/api&version=GBmain",
+			sub:          "docs",
+			direction:    DirectionUp,
+			wantPath:     "docs",
+			wantUrl:      "https://dev.azure.com/org/project/_git/repo?path=/docs&version=GBmain",
+			wantCloneUrl: "https://dev.azure.com/org/project/_git/repo",
+		},
+		{
+			name:         "Azure DevOps Sub Folder Root",
+			url:          "https://dev.azure.com/org/project/_git/repo?path=/docs/api&version=GBmain",
+			sub:          "root",
+			direction:    DirectionNone,
+			wantPath:     "",
+			wantUrl:      "https://dev.azure.com/org/project/_git/repo?version=GBmain",
+			wantCloneUrl: "https://dev.azure.com/org/project/_git/repo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGitRepository("", "", tt.url, "")
+			if err := r.Parse(tt.sub, tt.direction, ""); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+
+			// repository path stays, sub folder changes path only
+			want := NewGitRepository("", "", tt.wantUrl, "")
+			if err := want.Parse("", DirectionNone, ""); err != nil {
+				t.Fatalf("GitRepository.Parse(%q) error = %v", tt.wantUrl, err)
+			}
+			if r.Path != tt.wantPath || r.Url != tt.wantUrl || r.CloneUrl != tt.wantCloneUrl || r.RawPath != want.RawPath || r.ArchiveUrl != want.ArchiveUrl || r.QueryUrl != want.QueryUrl {
+				t.Errorf("GitRepository.Parse() = %#v, want %#v", r, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package gitrepository
+
+import (
+	"sync"
+	"testing"
+)
+
+// one url of every provider, folder urls with branch to walk routes
+var benchmarkUrls = []struct {
+	name string
+	url  string
+}{
+	{name: "GitHub", url: "https://github.com/cli/cli/tree/trunk/pkg/cmd/repo"},
+	{name: "GitLab", url: "https://gitlab.com/gitlab-org/gitlab/-/blob/master/app/models/user.rb"},
+	{name: "GitLabSubgroups", url: "https://gitlab.com/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/tree/main/materials?ref_type=heads"},
+	{name: "Bitbucket", url: "https://bitbucket.org/atlassian/atlaskit-mk-2/src/master/packages/"},
+	{name: "Gitea", url: "https://gitea.com/gitea/tea/src/branch/main/cmd/login.go"},
+	{name: "Forgejo", url: "https://codeberg.org/forgejo/forgejo/src/tag/v7.0.0/models"},
+	{name: "Gogs", url: "https://try.gogs.io/gogs/gogs/src/main/internal/conf"},
+	{name: "Gitee", url: "https://gitee.com/openharmony/docs/blob/master/README.md"},
+	{name: "GitCode", url: "https://gitcode.com/openharmony/docs/tree/master/zh-cn"},
+	{name: "Pagure", url: "https://pagure.io/pagure/blob/master/f/pagure/lib"},
+	{name: "AzureDevOps", url: "https://dev.azure.com/org/project/_git/repo?path=/src/app&version=GBmain"},
+	{name: "BitbucketServer", url: "https://git.example.com/projects/PRJ/repos/repo/browse/src/main.go?at=refs%2Fheads%2Fmain"},
+	{name: "CodeCommit", url: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo/browse/refs/heads/main/--/src?region=us-east-1"},
+	{name: "Gitiles", url: "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc/"},
+	{name: "SourceHut", url: "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/src"},
+	{name: "HuggingFace", url: "https://huggingface.co/datasets/openai/gsm8k/blob/main/README.md"},
+	{name: "Launchpad", url: "https://git.launchpad.net/~ubuntu-core-dev/ubuntu/+source/systemd/+git/systemd/tree/src?h=ubuntu/main"},
+	{name: "Cgit", url: "https://git.kernel.org/pub/scm/git/git.git/tree/Documentation?h=master"},
+	{name: "Gitweb", url: "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=blob;f=README;hb=refs/heads/master"},
+	{name: "Unknown", url: "https://git.example.io/owner/repo/tree/main/docs"},
+	{name: "ScpStyle", url: "git@github.com:cli/cli.git"},
+}
+
+func BenchmarkParseURL(b *testing.B) {
+	for _, bb := range benchmarkUrls {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseURL(bb.url); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseURL_Parallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if _, err := ParseURL(benchmarkUrls[i%len(benchmarkUrls)].url); err != nil {
+				b.Fatal(err)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkGitRepository_Parse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r := NewGitRepository("", "", benchmarkUrls[i%len(benchmarkUrls)].url, "")
+		if err := r.Parse("", DirectionNone, ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// parsers share no state, goroutines get the same locations
+func TestParseURL_Concurrent(t *testing.T) {
+	want := make([]Location, len(benchmarkUrls))
+	for i, bb := range benchmarkUrls {
+		l, err := ParseURL(bb.url)
+		if err != nil {
+			t.Fatalf("ParseURL(%q) error = %v", bb.url, err)
+		}
+		want[i] = l
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				for i, bb := range benchmarkUrls {
+					if l, err := ParseURL(bb.url); err != nil || l != want[i] {
+						t.Errorf("ParseURL(%q) = %#v, %v, want %#v", bb.url, l, err, want[i])
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
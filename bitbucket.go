@@ -0,0 +1,55 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+)
+
+// bitbucket.org provider, positional routes with src segment
+type bitbucketProvider struct {
+	GenericProvider
+}
+
+func (bitbucketProvider) Forge() string {
+	return ForgeBitbucket
+}
+
+func (bitbucketProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "bitbucket.org")
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/src/[BRANCH]/[PATH]
+func (p bitbucketProvider) BrowseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + escapePath(p.routePath(l))
+}
+
+// owners and names are case insensitive
+func (bitbucketProvider) foldCase(l *Location) {
+	l.lowerOwnerName()
+}
+
+// src route of bitbucket, blob urls keep blob
+func (bitbucketProvider) routePath(l *Location) string {
+	route := l.getPositionalRoute()
+	if route != "blob" {
+		route = "src"
+	}
+
+	return l.getPositionalPath(route)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/get/[BRANCH].[EXT]
+func (bitbucketProvider) ArchiveUrl(l *Location) string {
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/get/" + l.Branch + ".zip"
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/raw/[BRANCH]/[PATH]
+// https://bitbucket.org/micovery/sock-rpc/raw/v1.0.0/package.json
+func (bitbucketProvider) FileUrl(l *Location, path string) string {
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/raw/" + l.Branch + "/" + path
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/src/[BRANCH]/[PATH]
+func (bitbucketProvider) QueryUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/src/" + filepath.Join(l.Branch, path) + "/"
+}
@@ -0,0 +1,223 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// bitbucket server (data center) default ssh port
+const bitbucketServerSshPort = "7999"
+
+// detect self-hosted bitbucket server urls by routes
+// /projects/<KEY>/repos/<repo>, /users/<user>/repos/<repo>, /scm/<key>/<repo>.git, ssh port 7999
+func isBitbucketServerUrl(u *url.URL) bool {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(segments) >= 4 && (segments[0] == "projects" || segments[0] == "users") && segments[2] == "repos":
+		return true
+	case len(segments) == 3 && segments[0] == "scm":
+		return true
+	case u.Port() == bitbucketServerSshPort:
+		return true
+	}
+
+	return false
+}
+
+// bitbucket server provider, self-hosted instances only
+type bitbucketServerProvider struct {
+	GenericProvider
+}
+
+func (bitbucketServerProvider) Forge() string {
+	return ForgeBitbucketServer
+}
+
+// unknown hostnames: detect by url routes
+func (bitbucketServerProvider) Match(u *url.URL) bool {
+	return isBitbucketServerUrl(u)
+}
+
+func (bitbucketServerProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseBitbucketServerRoute(u, filename)
+}
+
+// https://[HOSTNAME]/projects/[OWNER]/repos/[NAME]
+func (bitbucketServerProvider) BaseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + "/" + l.getBitbucketServerRepoPath()
+}
+
+// project keys are uppercase, users and names lowercase
+func (bitbucketServerProvider) foldCase(l *Location) {
+	if strings.HasPrefix(l.Owner, "~") {
+		l.lowerOwnerName()
+	} else {
+		l.Owner, l.Name = strings.ToUpper(l.Owner), strings.ToLower(l.Name)
+	}
+	l.RawPath = "/" + l.getBitbucketServerRepoPath()
+}
+
+// ref lives in query string
+func (bitbucketServerProvider) BrowseUrl(l *Location) string {
+	return l.getBitbucketServerBrowseUrl(l.Path)
+}
+
+// browse urls of files without extension parse back as folders, raw route only serves files
+// folders end with slash, dotted folder names parse back as folders
+// https://[HOSTNAME]/projects/[OWNER]/repos/[NAME]/raw/[PATH]?at=[REF]
+func (bitbucketServerProvider) canonicalUrl(l *Location) string {
+	switch {
+	case l.Path == "":
+		return l.getBitbucketServerBrowseUrl("")
+	case l.IsFile:
+		return l.getBaseUrl() + "/raw/" + escapePath(l.Path) + l.getBitbucketServerAtQuery("?")
+	}
+
+	return l.getBitbucketServerBrowseUrl(l.Path + "/")
+}
+
+// https://[HOSTNAME]/scm/[OWNER]/[NAME].git
+func (bitbucketServerProvider) CloneUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + "/scm/" + strings.ToLower(l.Owner) + "/" + l.Name + ".git"
+}
+
+// ssh://git@[HOSTNAME]:7999/[OWNER]/[NAME].git
+func (bitbucketServerProvider) RemoteUrl(l *Location) string {
+	return "ssh://git@" + l.Hostname + ":" + bitbucketServerSshPort + "/" + strings.ToLower(l.Owner) + "/" + l.Name + ".git"
+}
+
+// https://[HOSTNAME]/rest/api/latest/projects/[OWNER]/repos/[NAME]/archive?at=[REF]&path=[PATH]&format=zip
+func (bitbucketServerProvider) ArchiveUrl(l *Location) string {
+	return l.getBitbucketServerArchiveUrl()
+}
+
+// https://[HOSTNAME]/projects/[OWNER]/repos/[NAME]/raw/[PATH]?at=[REF]
+func (bitbucketServerProvider) FileUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/raw/" + path + l.getBitbucketServerAtQuery("?")
+}
+
+// https://[HOSTNAME]/projects/[OWNER]/repos/[NAME]/browse/[PATH]?at=[REF]
+func (bitbucketServerProvider) QueryUrl(l *Location, path string) string {
+	return l.getBitbucketServerBrowseUrl(path)
+}
+
+// parse bitbucket server routes
+/*
+https://<hostname>/projects/<KEY>/repos/<repo>/browse/<path>?at=refs%2Fheads%2F<branch>
+https://<hostname>/projects/<KEY>/repos/<repo>/raw/<path>?at=refs%2Ftags%2F<tag>
+https://<hostname>/users/<user>/repos/<repo>/browse/<path>?at=<commit>
+https://<hostname>/scm/<key>/<repo>.git
+ssh://git@<hostname>:7999/<key>/<repo>.git
+*/
+func (l *Location) parseBitbucketServerRoute(u *url.URL, filename string) error {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var rest []string
+	switch {
+	case len(segments) >= 4 && segments[0] == "projects" && segments[2] == "repos":
+		l.Owner, l.Name, rest = segments[1], segments[3], segments[4:]
+	case len(segments) >= 4 && segments[0] == "users" && segments[2] == "repos":
+		l.Owner, l.Name, rest = "~"+segments[1], segments[3], segments[4:]
+	case len(segments) == 3 && segments[0] == "scm":
+		l.Owner, l.Name = segments[1], segments[2]
+	case len(segments) == 2 && l.Protocol == "ssh":
+		l.Owner, l.Name = segments[0], segments[1]
+	case segments[0] == "":
+		return newParseError(ErrMissingOwner, "")
+	case segments[0] == "projects" || segments[0] == "users" || segments[0] == "scm":
+		return newParseError(ErrMissingRepo, "")
+	default:
+		return newParseError(ErrUnknownRoute, segments[0])
+	}
+
+	// project keys are uppercase, clone urls use lowercase keys
+	if !strings.HasPrefix(l.Owner, "~") {
+		l.Owner = strings.ToUpper(l.Owner)
+	}
+	l.Name = trimGitSuffix(l.Name)
+	switch {
+	case l.Owner == "" || l.Owner == "~":
+		return newParseError(ErrMissingOwner, "")
+	case l.Name == "":
+		return newParseError(ErrMissingRepo, "")
+	}
+	l.RawPath = "/" + l.getBitbucketServerRepoPath()
+
+	// browse|raw routes
+	route := ""
+	if len(rest) > 0 {
+		route = rest[0]
+		l.traceRoute(route)
+		if route != "browse" && route != "raw" {
+			return newParseError(ErrUnknownRoute, route)
+		}
+		l.Path = strings.Join(rest[1:], "/")
+	}
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+
+	// at query: refs/heads/<branch>, refs/tags/<tag>, <commit>
+	if at := u.Query().Get("at"); at != "" {
+		l.Branch, l.RefKind = splitRef(at)
+	}
+
+	// route evidence first: filename, raw route only serves files, trailing slash of folders
+	// fallback: browse urls do not tell file or folder, file names have an extension
+	switch {
+	case filename != "" || (route == "raw" && l.Path != ""):
+		l.IsFile = true
+	case l.Path == "" || strings.HasSuffix(u.Path, "/"):
+		l.IsFile = false
+	default:
+		l.IsFile = filepath.Ext(l.Path) != ""
+	}
+
+	return nil
+}
+
+// generate bitbucket server repository path
+// projects/[OWNER]/repos/[NAME] or users/[USER]/repos/[NAME]
+func (l *Location) getBitbucketServerRepoPath() string {
+	if user, ok := strings.CutPrefix(l.Owner, "~"); ok {
+		return "users/" + user + "/repos/" + l.Name
+	}
+
+	return "projects/" + l.Owner + "/repos/" + l.Name
+}
+
+// generate bitbucket server at query
+// refs/heads/[BRANCH], refs/tags/[TAG], [COMMIT]
+func (l *Location) getBitbucketServerAtQuery(separator string) string {
+	ref := l.getFullRef()
+	if ref == "" {
+		return ""
+	}
+
+	return separator + "at=" + url.QueryEscape(ref)
+}
+
+// generate bitbucket server web url
+// https://[HOSTNAME]/projects/[OWNER]/repos/[NAME]/browse/[PATH]?at=[REF]
+func (l *Location) getBitbucketServerBrowseUrl(path string) string {
+	at := l.getBitbucketServerAtQuery("?")
+	if path == "" && at == "" {
+		return l.getBaseUrl()
+	}
+
+	return l.getBaseUrl() + "/browse/" + escapePath(path) + at
+}
+
+// generate bitbucket server archive rest api url, folders archived alone
+// https://[HOSTNAME]/rest/api/latest/projects/[OWNER]/repos/[NAME]/archive?at=[REF]&path=[PATH]&format=zip
+func (l *Location) getBitbucketServerArchiveUrl() string {
+	query := []string{}
+	if at := l.getBitbucketServerAtQuery(""); at != "" {
+		query = append(query, at)
+	}
+	if l.Path != "" && !l.IsFile {
+		query = append(query, "path="+url.QueryEscape(l.Path))
+	}
+	query = append(query, "format=zip")
+
+	return l.Scheme + "://" + l.Hostname + "/rest/api/latest/" + l.getBitbucketServerRepoPath() + "/archive?" + strings.Join(query, "&")
+}
@@ -0,0 +1,355 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_BitbucketServerParse(t *testing.T) {
+	restoreRegistry(t)
+	RegisterForge("code.example.com", ForgeBitbucketServer)
+
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Bitbucket Server Repository",
+			url:    "https://git.corp/projects/KEY/repos/slug/browse",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.corp/projects/KEY/repos/slug",
+				RawUrl:       "https://git.corp/projects/KEY/repos/slug/browse",
+				CloneUrl:     "https://git.corp/scm/key/slug.git",
+				RemoteUrl:    "ssh://git@git.corp:7999/key/slug.git",
+				QueryUrl:     "https://git.corp/projects/KEY/repos/slug",
+				DirPath:      keyDirPath(Key{Hostname: "git.corp", Namespace: "KEY", Name: "slug", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.corp",
+				Forge:        ForgeBitbucketServer,
+				RawPath:      "/projects/KEY/repos/slug",
+				Path:         "",
+				Owner:        "KEY",
+				Name:         "slug",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.corp/rest/api/latest/projects/KEY/repos/slug/archive?format=zip",
+				FileUrl:      "https://git.corp/projects/KEY/repos/slug/raw/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Bitbucket Server Repository Some Folder",
+			url:    "https://git.corp/projects/KEY/repos/slug/browse/cmd?at=refs%2Fheads%2Fmain",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.corp/projects/KEY/repos/slug/browse/cmd?at=refs%2Fheads%2Fmain",
+				RawUrl:       "https://git.corp/projects/KEY/repos/slug/browse/cmd?at=refs%2Fheads%2Fmain",
+				CloneUrl:     "https://git.corp/scm/key/slug.git",
+				RemoteUrl:    "ssh://git@git.corp:7999/key/slug.git",
+				QueryUrl:     "https://git.corp/projects/KEY/repos/slug/browse/cmd?at=refs%2Fheads%2Fmain",
+				DirPath:      keyDirPath(Key{Hostname: "git.corp", Namespace: "KEY", Name: "slug", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.corp",
+				Forge:        ForgeBitbucketServer,
+				RawPath:      "/projects/KEY/repos/slug",
+				Path:         "cmd",
+				Owner:        "KEY",
+				Name:         "slug",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.corp/rest/api/latest/projects/KEY/repos/slug/archive?at=refs%2Fheads%2Fmain&path=cmd&format=zip",
+				FileUrl:      "https://git.corp/projects/KEY/repos/slug/raw/[PATH]?at=refs%2Fheads%2Fmain",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Bitbucket Server Slashes Branch Name Single File",
+			url:    "https://git.corp/projects/KEY/repos/slug/browse/path/file.go?at=refs%2Fheads%2Ffeature%2Flogin",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.corp/projects/KEY/repos/slug/browse/path/file.go?at=refs%2Fheads%2Ffeature%2Flogin",
+				RawUrl:       "https://git.corp/projects/KEY/repos/slug/browse/path/file.go?at=refs%2Fheads%2Ffeature%2Flogin",
+				CloneUrl:     "https://git.corp/scm/key/slug.git",
+				RemoteUrl:    "ssh://git@git.corp:7999/key/slug.git",
+				QueryUrl:     "https://git.corp/projects/KEY/repos/slug/browse/path?at=refs%2Fheads%2Ffeature%2Flogin",
+				DirPath:      keyDirPath(Key{Hostname: "git.corp", Namespace: "KEY", Name: "slug", RefKind: RefBranch, Ref: "feature/login"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.corp",
+				Forge:        ForgeBitbucketServer,
+				RawPath:      "/projects/KEY/repos/slug",
+				Path:         "path/file.go",
+				Owner:        "KEY",
+				Name:         "slug",
+				DummyBranch:  "gitd-branch",
+				Branch:       "feature/login",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.corp/rest/api/latest/projects/KEY/repos/slug/archive?at=refs%2Fheads%2Ffeature%2Flogin&format=zip",
+				FileUrl:      "https://git.corp/projects/KEY/repos/slug/raw/[PATH]?at=refs%2Fheads%2Ffeature%2Flogin",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Bitbucket Server Tag Folder",
+			url:    "https://git.corp/projects/KEY/repos/slug/browse/cmd/?at=refs%2Ftags%2Fv1.0.0",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.corp/projects/KEY/repos/slug/browse/cmd?at=refs%2Ftags%2Fv1.0.0",
+				RawUrl:       "https://git.corp/projects/KEY/repos/slug/browse/cmd/?at=refs%2Ftags%2Fv1.0.0",
+				CloneUrl:     "https://git.corp/scm/key/slug.git",
+				RemoteUrl:    "ssh://git@git.corp:7999/key/slug.git",
+				QueryUrl:     "https://git.corp/projects/KEY/repos/slug/browse/cmd?at=refs%2Ftags%2Fv1.0.0",
+				DirPath:      keyDirPath(Key{Hostname: "git.corp", Namespace: "KEY", Name: "slug", RefKind: RefTag, Ref: "v1.0.0"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.corp",
+				Forge:        ForgeBitbucketServer,
+				RawPath:      "/projects/KEY/repos/slug",
+				Path:         "cmd",
+				Owner:        "KEY",
+				Name:         "slug",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v1.0.0",
+				RefKind:      RefTag,
+				ArchiveUrl:   "https://git.corp/rest/api/latest/projects/KEY/repos/slug/archive?at=refs%2Ftags%2Fv1.0.0&path=cmd&format=zip",
+				FileUrl:      "https://git.corp/projects/KEY/repos/slug/raw/[PATH]?at=refs%2Ftags%2Fv1.0.0",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Bitbucket Server Personal Repository Raw File",
+			url:    "https://git.corp/users/jdoe/repos/dotfiles/raw/bin/setup?at=0123456789abcdef0123456789abcdef01234567",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.corp/users/jdoe/repos/dotfiles/browse/bin/setup?at=0123456789abcdef0123456789abcdef01234567",
+				RawUrl:       "https://git.corp/users/jdoe/repos/dotfiles/raw/bin/setup?at=0123456789abcdef0123456789abcdef01234567",
+				CloneUrl:     "https://git.corp/scm/~jdoe/dotfiles.git",
+				RemoteUrl:    "ssh://git@git.corp:7999/~jdoe/dotfiles.git",
+				QueryUrl:     "https://git.corp/users/jdoe/repos/dotfiles/browse/bin?at=0123456789abcdef0123456789abcdef01234567",
+				DirPath:      keyDirPath(Key{Hostname: "git.corp", Namespace: "~jdoe", Name: "dotfiles", RefKind: RefCommit, Ref: "0123456789abcdef0123456789abcdef01234567"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.corp",
+				Forge:        ForgeBitbucketServer,
+				RawPath:      "/users/jdoe/repos/dotfiles",
+				Path:         "bin/setup",
+				Owner:        "~jdoe",
+				Name:         "dotfiles",
+				DummyBranch:  "gitd-branch",
+				Branch:       "0123456789abcdef0123456789abcdef01234567",
+				RefKind:      RefCommit,
+				ArchiveUrl:   "https://git.corp/rest/api/latest/users/jdoe/repos/dotfiles/archive?at=0123456789abcdef0123456789abcdef01234567&format=zip",
+				FileUrl:      "https://git.corp/users/jdoe/repos/dotfiles/raw/[PATH]?at=0123456789abcdef0123456789abcdef01234567",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Bitbucket Server Clone Url",
+			url:    "https://git.corp/scm/key/slug.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.corp/projects/KEY/repos/slug",
+				RawUrl:       "https://git.corp/scm/key/slug.git",
+				CloneUrl:     "https://git.corp/scm/key/slug.git",
+				RemoteUrl:    "ssh://git@git.corp:7999/key/slug.git",
+				QueryUrl:     "https://git.corp/projects/KEY/repos/slug",
+				DirPath:      keyDirPath(Key{Hostname: "git.corp", Namespace: "KEY", Name: "slug", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.corp",
+				Forge:        ForgeBitbucketServer,
+				RawPath:      "/projects/KEY/repos/slug",
+				Path:         "",
+				Owner:        "KEY",
+				Name:         "slug",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.corp/rest/api/latest/projects/KEY/repos/slug/archive?format=zip",
+				FileUrl:      "https://git.corp/projects/KEY/repos/slug/raw/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Bitbucket Server SSH Url",
+			url:    "ssh://git@git.corp:7999/key/slug.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.corp/projects/KEY/repos/slug",
+				RawUrl:       "ssh://git@git.corp:7999/key/slug.git",
+				CloneUrl:     "https://git.corp/scm/key/slug.git",
+				RemoteUrl:    "ssh://git@git.corp:7999/key/slug.git",
+				QueryUrl:     "https://git.corp/projects/KEY/repos/slug",
+				DirPath:      keyDirPath(Key{Hostname: "git.corp", Namespace: "KEY", Name: "slug", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "git.corp",
+				Forge:        ForgeBitbucketServer,
+				RawPath:      "/projects/KEY/repos/slug",
+				Path:         "",
+				Owner:        "KEY",
+				Name:         "slug",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.corp/rest/api/latest/projects/KEY/repos/slug/archive?format=zip",
+				FileUrl:      "https://git.corp/projects/KEY/repos/slug/raw/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Bitbucket Server Registered Hostname SSH Url",
+			url:    "git@code.example.com:key/slug.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://code.example.com/projects/KEY/repos/slug",
+				RawUrl:       "git@code.example.com:key/slug.git",
+				CloneUrl:     "https://code.example.com/scm/key/slug.git",
+				RemoteUrl:    "ssh://git@code.example.com:7999/key/slug.git",
+				QueryUrl:     "https://code.example.com/projects/KEY/repos/slug",
+				DirPath:      keyDirPath(Key{Hostname: "code.example.com", Namespace: "KEY", Name: "slug", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "code.example.com",
+				Forge:        ForgeBitbucketServer,
+				RawPath:      "/projects/KEY/repos/slug",
+				Path:         "",
+				Owner:        "KEY",
+				Name:         "slug",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://code.example.com/rest/api/latest/projects/KEY/repos/slug/archive?format=zip",
+				FileUrl:      "https://code.example.com/projects/KEY/repos/slug/raw/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Bitbucket Server Not Valid Route",
+			url:    "https://git.corp/projects/KEY/repos/slug/commits",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://git.corp/projects/KEY/repos/slug/commits",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "git.corp",
+				Forge:       ForgeBitbucketServer,
+				RawPath:     "/projects/KEY/repos/slug",
+				Path:        "",
+				Owner:       "KEY",
+				Name:        "slug",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+func TestGitRepository_BitbucketServerFileEvidence(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		filename   string
+		wantPath   string
+		wantIsFile bool
+	}{
+		{name: "Raw File", url: "https://git.corp/projects/PRJ/repos/repo/raw/bin/setup?at=refs%2Fheads%2Fmain", wantPath: "bin/setup", wantIsFile: true},
+		{name: "Dotted Folder", url: "https://git.corp/projects/PRJ/repos/repo/browse/v1.2/?at=refs%2Fheads%2Fmain", wantPath: "v1.2", wantIsFile: false},
+		{name: "Filename", url: "https://git.corp/projects/PRJ/repos/repo/browse/bin?at=refs%2Fheads%2Fmain", filename: "setup", wantPath: "bin/setup", wantIsFile: true},
+		{name: "Extension Fallback", url: "https://git.corp/projects/PRJ/repos/repo/browse/go.mod?at=refs%2Fheads%2Fmain", wantPath: "go.mod", wantIsFile: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGitRepository("", "", tt.url, "")
+			if err := r.Parse("", DirectionNone, tt.filename); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+			if r.Path != tt.wantPath || r.IsFile != tt.wantIsFile {
+				t.Errorf("GitRepository.Parse() Path = %q, IsFile = %v, want %q, %v", r.Path, r.IsFile, tt.wantPath, tt.wantIsFile)
+			}
+		})
+	}
+}
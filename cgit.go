@@ -0,0 +1,186 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// cgit route keywords after repository path
+var cgitRoutes = map[string]bool{
+	"tree": true, "plain": true, "blob": true, "blame": true, "snapshot": true,
+	"summary": true, "about": true, "refs": true, "log": true, "commit": true,
+	"diff": true, "patch": true, "stats": true, "atom": true, "tag": true,
+}
+
+// snapshot archive extensions of cgit
+var cgitSnapshotExtensions = []string{".tar.gz", ".tar.bz2", ".tar.xz", ".tar.zst", ".tar", ".zip"}
+
+// well-known cgit and gitweb hostnames, some serve both front-ends
+// self-hosted cgit routes look like generic paths, RegisterForge(hostname, ForgeCgit) detects them
+var cgitHostnames = []string{"git.kernel.org", "git.savannah.gnu.org", "git.zx2c4.com"}
+
+// cgit provider, repositories have deep paths and refs live in query string
+type cgitProvider struct {
+	GenericProvider
+}
+
+func (cgitProvider) Forge() string {
+	return ForgeCgit
+}
+
+func (cgitProvider) Match(u *url.URL) bool {
+	return matchHostname(u, cgitHostnames...)
+}
+
+func (cgitProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseCgitRoute(u, filename)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME].git
+func (cgitProvider) BaseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + l.RawPath
+}
+
+// branch lives in query string
+func (cgitProvider) BrowseUrl(l *Location) string {
+	return l.getCgitTreeUrl(l.Path)
+}
+
+// tree urls of files without extension parse back as folders, plain route only serves files
+// https://[HOSTNAME]/[OWNER]/[NAME].git/plain/[PATH]?h=[BRANCH]
+func (cgitProvider) canonicalUrl(l *Location) string {
+	if l.IsFile {
+		return l.getBaseUrl() + "/plain/" + escapePath(l.Path) + l.getCgitRefQuery()
+	}
+
+	return l.getCgitTreeUrl(l.Path)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME].git - cgit http clone
+func (cgitProvider) CloneUrl(l *Location) string {
+	return l.getBaseUrl()
+}
+
+// git://[HOSTNAME]/[OWNER]/[NAME].git - git daemon
+func (cgitProvider) RemoteUrl(l *Location) string {
+	return "git://" + l.Hostname + l.RawPath
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME].git/snapshot/[NAME]-[BRANCH].tar.gz
+// HEAD if branch is empty
+func (cgitProvider) ArchiveUrl(l *Location) string {
+	branch := l.Branch
+	if branch == "" {
+		branch = "HEAD"
+	}
+	return l.getBaseUrl() + "/snapshot/" + l.Name + "-" + branch + ".tar.gz"
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME].git/plain/[PATH]?h=[BRANCH]
+// https://git.kernel.org/pub/scm/git/git.git/plain/README.md?h=master
+func (cgitProvider) FileUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/plain/" + path + l.getCgitRefQuery()
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME].git/tree/[PATH]?h=[BRANCH]
+func (cgitProvider) QueryUrl(l *Location, path string) string {
+	return l.getCgitTreeUrl(path)
+}
+
+// parse cgit routes, repository path may be deep and ends before route keyword
+/*
+https://<hostname>/<deep>/<repo>.git
+https://<hostname>/<deep>/<repo>.git/tree/<path>?h=<branch> -> owner: <deep>
+https://<hostname>/<deep>/<repo>.git/tree/<path>?h=<branch>&id=<commit> -> commit first, id must be a full commit hash
+https://<hostname>/<deep>/<repo>.git/plain/<path>?h=<branch> -> single file
+https://<hostname>/<deep>/<repo>.git/snapshot/<repo>-<branch>.tar.gz
+*/
+func (l *Location) parseCgitRoute(u *url.URL, filename string) error {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	index := len(segments)
+	for i := 1; i < len(segments); i++ {
+		if cgitRoutes[segments[i]] {
+			index = i
+			break
+		}
+	}
+
+	return l.parseCgitSegments(u, segments, index, filename)
+}
+
+// parse cgit route after repository path, segments[:index] is repository path
+func (l *Location) parseCgitSegments(u *url.URL, segments []string, index int, filename string) error {
+	repository, route, rest := segments[:index], "", []string{}
+	if index < len(segments) {
+		route, rest = segments[index], segments[index+1:]
+	}
+
+	l.Owner = strings.Join(repository[:len(repository)-1], "/")
+	l.Name = trimGitSuffix(repository[len(repository)-1])
+	if l.Name == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+	l.RawPath = "/" + strings.Join(repository, "/")
+
+	// h=<branch>, id=<commit>
+	query := u.Query()
+	if h := query.Get("h"); h != "" {
+		l.Branch, l.RefKind = splitRef(h)
+	}
+	if id := query.Get("id"); id != "" {
+		if !isCommitHash(id) {
+			return newParseError(ErrInvalidRef, id)
+		}
+		l.Branch, l.RefKind = id, RefCommit
+	}
+
+	l.traceRoute(route)
+	switch route {
+	case "tree", "plain", "blob", "blame":
+		l.Path = strings.Join(rest, "/")
+	case "snapshot":
+		// <repo>-<branch>.tar.gz
+		if len(rest) != 1 {
+			return newParseError(ErrInvalidRef, strings.Join(rest, "/"))
+		}
+		snapshot := strings.TrimPrefix(rest[0], l.Name+"-")
+		for _, extension := range cgitSnapshotExtensions {
+			if ref, ok := strings.CutSuffix(snapshot, extension); ok {
+				l.Branch, l.RefKind = splitRef(ref)
+				break
+			}
+		}
+	}
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+
+	// plain route only serves files, tree urls do not tell file or folder
+	l.IsFile = filename != "" || (route == "plain" && l.Path != "") || (l.Path != "" && !strings.HasSuffix(u.Path, "/") && filepath.Ext(l.Path) != "")
+
+	return nil
+}
+
+// generate cgit ref query
+// branches and tags h=[BRANCH], commits id=[COMMIT]
+func (l *Location) getCgitRefQuery() string {
+	if l.Branch == "" {
+		return ""
+	}
+
+	if l.RefKind == RefCommit {
+		return "?id=" + url.QueryEscape(l.Branch)
+	}
+
+	return "?h=" + url.QueryEscape(l.Branch)
+}
+
+// generate cgit web url
+// https://[HOSTNAME]/[OWNER]/[NAME].git/tree/[PATH]?h=[BRANCH]
+func (l *Location) getCgitTreeUrl(path string) string {
+	if l.Branch == "" && path == "" {
+		return l.getBaseUrl()
+	}
+
+	return l.getBaseUrl() + "/tree/" + escapePath(path) + l.getCgitRefQuery()
+}
@@ -0,0 +1,314 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_CgitParse(t *testing.T) {
+	restoreRegistry(t)
+	RegisterForge("cgit.example.org", ForgeCgit)
+
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Cgit Repository",
+			url:    "https://git.kernel.org/pub/scm/git/git.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.kernel.org/pub/scm/git/git.git",
+				RawUrl:       "https://git.kernel.org/pub/scm/git/git.git",
+				CloneUrl:     "https://git.kernel.org/pub/scm/git/git.git",
+				RemoteUrl:    "git://git.kernel.org/pub/scm/git/git.git",
+				QueryUrl:     "https://git.kernel.org/pub/scm/git/git.git",
+				DirPath:      keyDirPath(Key{Hostname: "git.kernel.org", Namespace: "pub/scm/git", Name: "git", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.kernel.org",
+				Forge:        ForgeCgit,
+				RawPath:      "/pub/scm/git/git.git",
+				Path:         "",
+				Owner:        "pub/scm/git",
+				Name:         "git",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.kernel.org/pub/scm/git/git.git/snapshot/git-HEAD.tar.gz",
+				FileUrl:      "https://git.kernel.org/pub/scm/git/git.git/plain/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Cgit Repository Some Folder",
+			url:    "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/kernel?h=master",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/kernel?h=master",
+				RawUrl:       "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/kernel?h=master",
+				CloneUrl:     "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git",
+				RemoteUrl:    "git://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git",
+				QueryUrl:     "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/kernel?h=master",
+				DirPath:      keyDirPath(Key{Hostname: "git.kernel.org", Namespace: "pub/scm/linux/kernel/git/torvalds", Name: "linux", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.kernel.org",
+				Forge:        ForgeCgit,
+				RawPath:      "/pub/scm/linux/kernel/git/torvalds/linux.git",
+				Path:         "kernel",
+				Owner:        "pub/scm/linux/kernel/git/torvalds",
+				Name:         "linux",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/snapshot/linux-master.tar.gz",
+				FileUrl:      "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/plain/[PATH]?h=master",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Cgit Commit Single File",
+			url:    "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/kernel/fork.c?h=master&id=0123456789abcdef0123456789abcdef01234567",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/kernel/fork.c?id=0123456789abcdef0123456789abcdef01234567",
+				RawUrl:       "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/kernel/fork.c?h=master&id=0123456789abcdef0123456789abcdef01234567",
+				CloneUrl:     "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git",
+				RemoteUrl:    "git://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git",
+				QueryUrl:     "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/kernel?id=0123456789abcdef0123456789abcdef01234567",
+				DirPath:      keyDirPath(Key{Hostname: "git.kernel.org", Namespace: "pub/scm/linux/kernel/git/torvalds", Name: "linux", RefKind: RefCommit, Ref: "0123456789abcdef0123456789abcdef01234567"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.kernel.org",
+				Forge:        ForgeCgit,
+				RawPath:      "/pub/scm/linux/kernel/git/torvalds/linux.git",
+				Path:         "kernel/fork.c",
+				Owner:        "pub/scm/linux/kernel/git/torvalds",
+				Name:         "linux",
+				DummyBranch:  "gitd-branch",
+				Branch:       "0123456789abcdef0123456789abcdef01234567",
+				RefKind:      RefCommit,
+				ArchiveUrl:   "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/snapshot/linux-0123456789abcdef0123456789abcdef01234567.tar.gz",
+				FileUrl:      "https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/plain/[PATH]?id=0123456789abcdef0123456789abcdef01234567",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Cgit Plain Single File",
+			url:    "https://git.savannah.gnu.org/cgit/emacs.git/plain/README?h=emacs-29",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.savannah.gnu.org/cgit/emacs.git/tree/README?h=emacs-29",
+				RawUrl:       "https://git.savannah.gnu.org/cgit/emacs.git/plain/README?h=emacs-29",
+				CloneUrl:     "https://git.savannah.gnu.org/cgit/emacs.git",
+				RemoteUrl:    "git://git.savannah.gnu.org/cgit/emacs.git",
+				QueryUrl:     "https://git.savannah.gnu.org/cgit/emacs.git/tree/?h=emacs-29",
+				DirPath:      keyDirPath(Key{Hostname: "git.savannah.gnu.org", Namespace: "cgit", Name: "emacs", RefKind: RefBranch, Ref: "emacs-29"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.savannah.gnu.org",
+				Forge:        ForgeCgit,
+				RawPath:      "/cgit/emacs.git",
+				Path:         "README",
+				Owner:        "cgit",
+				Name:         "emacs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "emacs-29",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.savannah.gnu.org/cgit/emacs.git/snapshot/emacs-emacs-29.tar.gz",
+				FileUrl:      "https://git.savannah.gnu.org/cgit/emacs.git/plain/[PATH]?h=emacs-29",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Cgit Snapshot Url",
+			url:    "https://git.kernel.org/pub/scm/git/git.git/snapshot/git-v2.40.0.tar.gz",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.kernel.org/pub/scm/git/git.git/tree/?h=v2.40.0",
+				RawUrl:       "https://git.kernel.org/pub/scm/git/git.git/snapshot/git-v2.40.0.tar.gz",
+				CloneUrl:     "https://git.kernel.org/pub/scm/git/git.git",
+				RemoteUrl:    "git://git.kernel.org/pub/scm/git/git.git",
+				QueryUrl:     "https://git.kernel.org/pub/scm/git/git.git/tree/?h=v2.40.0",
+				DirPath:      keyDirPath(Key{Hostname: "git.kernel.org", Namespace: "pub/scm/git", Name: "git", RefKind: RefBranch, Ref: "v2.40.0"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.kernel.org",
+				Forge:        ForgeCgit,
+				RawPath:      "/pub/scm/git/git.git",
+				Path:         "",
+				Owner:        "pub/scm/git",
+				Name:         "git",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v2.40.0",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.kernel.org/pub/scm/git/git.git/snapshot/git-v2.40.0.tar.gz",
+				FileUrl:      "https://git.kernel.org/pub/scm/git/git.git/plain/[PATH]?h=v2.40.0",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Cgit Registered Hostname Folder",
+			url:    "https://cgit.example.org/project.git/tree/docs/?h=refs/tags/v1.0.0",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://cgit.example.org/project.git/tree/docs?h=v1.0.0",
+				RawUrl:       "https://cgit.example.org/project.git/tree/docs/?h=refs/tags/v1.0.0",
+				CloneUrl:     "https://cgit.example.org/project.git",
+				RemoteUrl:    "git://cgit.example.org/project.git",
+				QueryUrl:     "https://cgit.example.org/project.git/tree/docs?h=v1.0.0",
+				DirPath:      keyDirPath(Key{Hostname: "cgit.example.org", Name: "project", RefKind: RefTag, Ref: "v1.0.0"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "cgit.example.org",
+				Forge:        ForgeCgit,
+				RawPath:      "/project.git",
+				Path:         "docs",
+				Owner:        "",
+				Name:         "project",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v1.0.0",
+				RefKind:      RefTag,
+				ArchiveUrl:   "https://cgit.example.org/project.git/snapshot/project-v1.0.0.tar.gz",
+				FileUrl:      "https://cgit.example.org/project.git/plain/[PATH]?h=v1.0.0",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+func TestGitRepository_CgitInvalidCommit(t *testing.T) {
+	// id query is a commit, refs and short hashes are not
+	for _, url := range []string{
+		"https://git.kernel.org/pub/scm/git/git.git/tree/README.md?h=master&id=HEAD~1",
+		"https://git.kernel.org/pub/scm/git/git.git/tree/README.md?id=v2.43.0",
+	} {
+		r := NewGitRepository("", "", url, "")
+		if err := r.Parse("", DirectionNone, ""); err == nil {
+			t.Errorf("GitRepository.Parse(%q) error = nil, Branch = %q", url, r.Branch)
+		}
+	}
+}
+
+func TestGitRepository_CgitSubFolder(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		sub          string
+		direction    Direction
+		wantPath     string
+		wantUrl      string
+		wantCloneUrl string
+	}{
+		{
+			name:         "Cgit Sub Folder Down",
+			url:          "https://git.kernel.org/pub/scm/git/git.git/tree/Documentation?h=master",
+			sub:          "docs",
+			direction:    DirectionDown,
+			wantPath:     "Documentation/docs",
+			wantUrl:      "https://git.kernel.org/pub/scm/git/git.git/tree/Documentation/docs?h=master",
+			wantCloneUrl: "https://git.kernel.org/pub/scm/git/git.git",
+		},
+		{
+			name:         "Cgit Sub Folder Up",
+			url:          "https://git.kernel.org/pub/scm/git/git.git/tree/Documentation/technical?h=master",
+			sub:          "Documentation",
+			direction:    DirectionUp,
+			wantPath:     "Documentation",
+			wantUrl:      "https://git.kernel.org/pub/scm/git/git.git/tree/Documentation?h=master",
+			wantCloneUrl: "https://git.kernel.org/pub/scm/git/git.git",
+		},
+		{
+			name:         "Cgit Sub Folder Root",
+			url:          "https://git.kernel.org/pub/scm/git/git.git/tree/Documentation/technical?h=master",
+			sub:          "root",
+			direction:    DirectionNone,
+			wantPath:     "",
+			wantUrl:      "https://git.kernel.org/pub/scm/git/git.git/tree/?h=master",
+			wantCloneUrl: "https://git.kernel.org/pub/scm/git/git.git",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGitRepository("", "", tt.url, "")
+			if err := r.Parse(tt.sub, tt.direction, ""); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+
+			// repository path stays, sub folder changes path only
+			want := NewGitRepository("", "", tt.wantUrl, "")
+			if err := want.Parse("", DirectionNone, ""); err != nil {
+				t.Fatalf("GitRepository.Parse(%q) error = %v", tt.wantUrl, err)
+			}
+			if r.Path != tt.wantPath || r.Url != tt.wantUrl || r.CloneUrl != tt.wantCloneUrl || r.RawPath != want.RawPath || r.ArchiveUrl != want.ArchiveUrl || r.QueryUrl != want.QueryUrl {
+				t.Errorf("GitRepository.Parse() = %#v, want %#v", r, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// detect aws codecommit urls
+// git-codecommit.<region>.amazonaws.com, <region>.console.aws.amazon.com/codesuite/codecommit, codecommit::<region>://
+func isCodeCommitUrl(u *url.URL) bool {
+	hostname := u.Hostname()
+	switch {
+	case u.Scheme == "codecommit":
+		return true
+	case strings.HasPrefix(hostname, "git-codecommit.") || strings.HasPrefix(hostname, "git-codecommit-fips."):
+		return strings.HasSuffix(hostname, ".amazonaws.com")
+	case strings.HasSuffix(hostname, "console.aws.amazon.com"):
+		return strings.HasPrefix(u.Path, "/codesuite/codecommit/")
+	}
+
+	return false
+}
+
+// aws codecommit provider
+type codeCommitProvider struct {
+	GenericProvider
+}
+
+func (codeCommitProvider) Forge() string {
+	return ForgeCodeCommit
+}
+
+// aws codecommit git, console and git-remote-codecommit urls
+func (codeCommitProvider) Match(u *url.URL) bool {
+	return isCodeCommitUrl(u)
+}
+
+func (codeCommitProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseCodeCommitRoute(u, filename)
+}
+
+// https://[REGION].console.aws.amazon.com/codesuite/codecommit/repositories/[NAME]/browse?region=[REGION]
+func (codeCommitProvider) BaseUrl(l *Location) string {
+	return l.getCodeCommitBrowseUrl("", "")
+}
+
+// aws console url
+func (codeCommitProvider) BrowseUrl(l *Location) string {
+	return l.getCodeCommitBrowseUrl(l.Branch, l.Path)
+}
+
+// console urls do not tell fips endpoints, folders end with slash
+// fips repositories are git urls, fips refs and paths are console urls with endpoint=fips
+func (codeCommitProvider) canonicalUrl(l *Location) string {
+	path := l.Path
+	if !l.IsFile && path != "" {
+		path += "/"
+	}
+	if !strings.HasPrefix(l.Hostname, "git-codecommit-fips.") {
+		return l.getCodeCommitBrowseUrl(l.Branch, path)
+	}
+	if l.Branch == "" && l.Path == "" {
+		return l.Scheme + "://" + l.Hostname + l.RawPath
+	}
+
+	return l.getCodeCommitBrowseUrl(l.Branch, path) + "&endpoint=fips"
+}
+
+// https://git-codecommit.[REGION].amazonaws.com/v1/repos/[NAME]
+func (codeCommitProvider) CloneUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + l.RawPath
+}
+
+// ssh://git-codecommit.[REGION].amazonaws.com/v1/repos/[NAME]
+func (codeCommitProvider) RemoteUrl(l *Location) string {
+	return "ssh://" + l.Hostname + l.RawPath
+}
+
+// Not supported: codecommit api requests are signed
+func (codeCommitProvider) ArchiveUrl(l *Location) string {
+	return ""
+}
+
+// Not supported: codecommit api requests are signed
+func (codeCommitProvider) FileUrl(l *Location, path string) string {
+	return ""
+}
+
+// https://[REGION].console.aws.amazon.com/codesuite/codecommit/repositories/[NAME]/browse/refs/heads/[BRANCH]/--/[PATH]?region=[REGION]
+func (codeCommitProvider) QueryUrl(l *Location, path string) string {
+	return l.getCodeCommitBrowseUrl(l.Branch, path)
+}
+
+// parse aws codecommit routes, codecommit repositories have no owner
+/*
+https://git-codecommit.<region>.amazonaws.com/v1/repos/<repo>
+ssh://git-codecommit.<region>.amazonaws.com/v1/repos/<repo>
+codecommit::<region>://[<profile>@]<repo> -> git-remote-codecommit helper, profile removes
+codecommit://[<profile>@]<repo>            -> missing region, region of aws environment is not parsed
+git-codecommit-fips.<region>.amazonaws.com -> fips hostname stays
+https://<region>.console.aws.amazon.com/codesuite/codecommit/repositories/<repo>/browse?region=<region>
+https://<region>.console.aws.amazon.com/codesuite/codecommit/repositories/<repo>/browse/refs/heads/<branch>/--/<path>?region=<region>
+https://<region>.console.aws.amazon.com/codesuite/codecommit/repositories/<repo>/browse?region=<region>&endpoint=fips -> fips hostname
+*/
+func (l *Location) parseCodeCommitRoute(u *url.URL, filename string) error {
+	prefix := "git-codecommit"
+	switch {
+	case u.Scheme == "codecommit" && u.Opaque == "":
+		// codecommit://[<profile>@]<repo>, region lives in aws environment of the helper
+		// parse does not read environment: same url, same location on every machine
+		l.Name = u.Host
+		l.Protocol = "codecommit"
+		l.Scheme = "https"
+	case u.Scheme == "codecommit":
+		// codecommit::<region>://[<profile>@]<repo>
+		region, repository, ok := strings.Cut(strings.TrimPrefix(u.Opaque, ":"), "://")
+		if !ok {
+			return newParseError(ErrUnknownRoute, u.Opaque)
+		}
+		if _, name, ok := strings.Cut(repository, "@"); ok {
+			repository = name
+		}
+		l.Region, l.Name = region, repository
+		l.Protocol = "codecommit"
+		l.Scheme = "https"
+	case strings.HasPrefix(u.Hostname(), "git-codecommit"):
+		// git-codecommit.<region>.amazonaws.com/v1/repos/<repo>
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		switch {
+		case segments[0] != "v1":
+			return newParseError(ErrUnknownRoute, segments[0])
+		case len(segments) < 2 || segments[1] != "repos":
+			return newParseError(ErrUnknownRoute, strings.Join(segments[1:], "/"))
+		case len(segments) < 3:
+			return newParseError(ErrMissingRepo, "")
+		case len(segments) > 3:
+			return newParseError(ErrUnknownRoute, segments[3])
+		}
+		// git-codecommit[-fips].<region>.amazonaws.com, no region in git-codecommit.amazonaws.com
+		hostnameSegments := strings.Split(u.Hostname(), ".")
+		if len(hostnameSegments) != 4 {
+			return newParseError(ErrMissingRegion, "")
+		}
+		l.Region, l.Name = hostnameSegments[1], segments[2]
+		prefix = hostnameSegments[0]
+	default:
+		// codesuite/codecommit/repositories/<repo>/browse/<ref>/--/<path>
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		switch {
+		case len(segments) < 3 || segments[2] != "repositories":
+			return newParseError(ErrUnknownRoute, strings.Join(segments, "/"))
+		case len(segments) < 4:
+			return newParseError(ErrMissingRepo, "")
+		}
+		l.Name = segments[3]
+
+		// endpoint=fips of canonical urls, console has no fips hostnames
+		if u.Query().Get("endpoint") == "fips" {
+			prefix = "git-codecommit-fips"
+		}
+
+		l.Region = u.Query().Get("region")
+		if l.Region == "" {
+			l.Region = strings.TrimSuffix(strings.TrimSuffix(u.Hostname(), "console.aws.amazon.com"), ".")
+		}
+
+		if len(segments) > 4 {
+			if segments[4] != "browse" {
+				return newParseError(ErrUnknownRoute, segments[4])
+			}
+
+			ref, path := segments[5:], []string{}
+			for i, segment := range ref {
+				if segment == "--" {
+					ref, path = ref[:i], ref[i+1:]
+					break
+				}
+			}
+			if len(ref) > 0 {
+				l.Branch, l.RefKind = splitRef(strings.Join(ref, "/"))
+			}
+			l.Path = strings.Join(path, "/")
+		}
+	}
+
+	switch {
+	case l.Region == "":
+		// region is the namespace of codecommit repositories, there is no owner
+		return newParseError(ErrMissingRegion, "")
+	case l.Name == "":
+		return newParseError(ErrMissingRepo, "")
+	}
+
+	l.Hostname = prefix + "." + l.Region + ".amazonaws.com"
+	l.RawPath = "/v1/repos/" + l.Name
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+
+	// route evidence first: filename, trailing slash of console folders
+	// fallback: console urls do not tell file or folder, file names have an extension
+	switch {
+	case filename != "":
+		l.IsFile = true
+	case l.Path == "" || strings.HasSuffix(u.Path, "/"):
+		l.IsFile = false
+	default:
+		l.IsFile = filepath.Ext(l.Path) != ""
+	}
+
+	return nil
+}
+
+// generate aws console codecommit web url
+// https://[REGION].console.aws.amazon.com/codesuite/codecommit/repositories/[NAME]/browse/refs/heads/[BRANCH]/--/[PATH]?region=[REGION]
+func (l *Location) getCodeCommitBrowseUrl(branch, path string) string {
+	browseUrl := "https://" + l.Region + ".console.aws.amazon.com/codesuite/codecommit/repositories/" + l.Name + "/browse"
+	if branch != "" {
+		switch l.RefKind {
+		case RefTag:
+			browseUrl += "/refs/tags/" + escapePath(branch)
+		case RefCommit:
+			browseUrl += "/" + branch
+		default:
+			browseUrl += "/refs/heads/" + escapePath(branch)
+		}
+		browseUrl += "/--/" + escapePath(path)
+	}
+
+	return browseUrl + "?region=" + l.Region
+}
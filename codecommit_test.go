@@ -0,0 +1,376 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_CodeCommitParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse CodeCommit Repository",
+			url:    "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=us-east-1",
+				RawUrl:       "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+				CloneUrl:     "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+				RemoteUrl:    "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+				QueryUrl:     "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=us-east-1",
+				DirPath:      keyDirPath(Key{Hostname: "git-codecommit.us-east-1.amazonaws.com", Namespace: "us-east-1", Name: "my-repo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git-codecommit.us-east-1.amazonaws.com",
+				Forge:        ForgeCodeCommit,
+				Region:       "us-east-1",
+				RawPath:      "/v1/repos/my-repo",
+				Path:         "",
+				Owner:        "",
+				Name:         "my-repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse CodeCommit SSH Repository",
+			url:    "ssh://git-codecommit.eu-central-1.amazonaws.com/v1/repos/my-repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://eu-central-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=eu-central-1",
+				RawUrl:       "ssh://git-codecommit.eu-central-1.amazonaws.com/v1/repos/my-repo",
+				CloneUrl:     "https://git-codecommit.eu-central-1.amazonaws.com/v1/repos/my-repo",
+				RemoteUrl:    "ssh://git-codecommit.eu-central-1.amazonaws.com/v1/repos/my-repo",
+				QueryUrl:     "https://eu-central-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=eu-central-1",
+				DirPath:      keyDirPath(Key{Hostname: "git-codecommit.eu-central-1.amazonaws.com", Namespace: "eu-central-1", Name: "my-repo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "git-codecommit.eu-central-1.amazonaws.com",
+				Forge:        ForgeCodeCommit,
+				Region:       "eu-central-1",
+				RawPath:      "/v1/repos/my-repo",
+				Path:         "",
+				Owner:        "",
+				Name:         "my-repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse CodeCommit Helper Repository",
+			url:    "codecommit::us-east-1://profile@my-repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=us-east-1",
+				RawUrl:       "codecommit::us-east-1://profile@my-repo",
+				CloneUrl:     "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+				RemoteUrl:    "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+				QueryUrl:     "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=us-east-1",
+				DirPath:      keyDirPath(Key{Hostname: "git-codecommit.us-east-1.amazonaws.com", Namespace: "us-east-1", Name: "my-repo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "codecommit",
+				Scheme:       "https",
+				Hostname:     "git-codecommit.us-east-1.amazonaws.com",
+				Forge:        ForgeCodeCommit,
+				Region:       "us-east-1",
+				RawPath:      "/v1/repos/my-repo",
+				Path:         "",
+				Owner:        "",
+				Name:         "my-repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse CodeCommit Console Repository",
+			url:    "https://console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=eu-west-1",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://eu-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=eu-west-1",
+				RawUrl:       "https://console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=eu-west-1",
+				CloneUrl:     "https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/my-repo",
+				RemoteUrl:    "ssh://git-codecommit.eu-west-1.amazonaws.com/v1/repos/my-repo",
+				QueryUrl:     "https://eu-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=eu-west-1",
+				DirPath:      keyDirPath(Key{Hostname: "git-codecommit.eu-west-1.amazonaws.com", Namespace: "eu-west-1", Name: "my-repo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git-codecommit.eu-west-1.amazonaws.com",
+				Forge:        ForgeCodeCommit,
+				Region:       "eu-west-1",
+				RawPath:      "/v1/repos/my-repo",
+				Path:         "",
+				Owner:        "",
+				Name:         "my-repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse CodeCommit Console Repository Some Folder",
+			url:    "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/main/--/src/app?region=us-east-1",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/main/--/src/app?region=us-east-1",
+				RawUrl:       "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/main/--/src/app?region=us-east-1",
+				CloneUrl:     "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+				RemoteUrl:    "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+				QueryUrl:     "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/main/--/src/app?region=us-east-1",
+				DirPath:      keyDirPath(Key{Hostname: "git-codecommit.us-east-1.amazonaws.com", Namespace: "us-east-1", Name: "my-repo", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git-codecommit.us-east-1.amazonaws.com",
+				Forge:        ForgeCodeCommit,
+				Region:       "us-east-1",
+				RawPath:      "/v1/repos/my-repo",
+				Path:         "src/app",
+				Owner:        "",
+				Name:         "my-repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse CodeCommit Console Slashes Branch Name Single File",
+			url:    "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/feature/login/--/src/app/main.go?region=us-east-1",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/feature/login/--/src/app/main.go?region=us-east-1",
+				RawUrl:       "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/feature/login/--/src/app/main.go?region=us-east-1",
+				CloneUrl:     "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+				RemoteUrl:    "ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+				QueryUrl:     "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/feature/login/--/src/app?region=us-east-1",
+				DirPath:      keyDirPath(Key{Hostname: "git-codecommit.us-east-1.amazonaws.com", Namespace: "us-east-1", Name: "my-repo", RefKind: RefBranch, Ref: "feature/login"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git-codecommit.us-east-1.amazonaws.com",
+				Forge:        ForgeCodeCommit,
+				Region:       "us-east-1",
+				RawPath:      "/v1/repos/my-repo",
+				Path:         "src/app/main.go",
+				Owner:        "",
+				Name:         "my-repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "feature/login",
+				RefKind:      RefBranch,
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse CodeCommit Helper Repository Without Region",
+			url:    "codecommit://profile@my-repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "codecommit://profile@my-repo",
+				IsFile:      false,
+				Protocol:    "codecommit",
+				Scheme:      "https",
+				Hostname:    "my-repo",
+				Forge:       ForgeCodeCommit,
+				Path:        "",
+				Owner:       "",
+				Name:        "my-repo",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Parse CodeCommit Fips Repository",
+			url:    "https://git-codecommit-fips.us-east-1.amazonaws.com/v1/repos/my-repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=us-east-1",
+				RawUrl:       "https://git-codecommit-fips.us-east-1.amazonaws.com/v1/repos/my-repo",
+				CloneUrl:     "https://git-codecommit-fips.us-east-1.amazonaws.com/v1/repos/my-repo",
+				RemoteUrl:    "ssh://git-codecommit-fips.us-east-1.amazonaws.com/v1/repos/my-repo",
+				QueryUrl:     "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse?region=us-east-1",
+				DirPath:      keyDirPath(Key{Hostname: "git-codecommit-fips.us-east-1.amazonaws.com", Namespace: "us-east-1", Name: "my-repo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git-codecommit-fips.us-east-1.amazonaws.com",
+				Forge:        ForgeCodeCommit,
+				Region:       "us-east-1",
+				RawPath:      "/v1/repos/my-repo",
+				Path:         "",
+				Owner:        "",
+				Name:         "my-repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse CodeCommit Hostname Without Region",
+			url:    "https://git-codecommit.amazonaws.com/v1/repos/my-repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://git-codecommit.amazonaws.com/v1/repos/my-repo",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "git-codecommit.amazonaws.com",
+				Forge:       ForgeCodeCommit,
+				Path:        "",
+				Owner:       "",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Parse CodeCommit Not Valid Route",
+			url:    "https://git-codecommit.us-east-1.amazonaws.com/v1/my-repo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://git-codecommit.us-east-1.amazonaws.com/v1/my-repo",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "git-codecommit.us-east-1.amazonaws.com",
+				Forge:       ForgeCodeCommit,
+				Path:        "",
+				Owner:       "",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+func TestGitRepository_CodeCommitIgnoresEnv(t *testing.T) {
+	t.Setenv("AWS_REGION", "us-east-2")
+	t.Setenv("AWS_DEFAULT_REGION", "eu-west-1")
+
+	// same url, same repository on every machine
+	r := NewGitRepository("", "", "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo", "")
+	if err := r.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+	if r.Region != "us-east-1" || r.Hostname != "git-codecommit.us-east-1.amazonaws.com" {
+		t.Errorf("GitRepository.Parse() Region = %q, Hostname = %q", r.Region, r.Hostname)
+	}
+
+	// region-less helper urls miss region
+	for _, url := range []string{"codecommit://my-repo", "codecommit://profile@my-repo", "codecommit::://my-repo"} {
+		r := NewGitRepository("", "", url, "")
+		if err := r.Parse("", DirectionNone, ""); err == nil {
+			t.Errorf("GitRepository.Parse(%q) error = nil, Region = %q", url, r.Region)
+		}
+	}
+}
+
+func TestGitRepository_CodeCommitFileEvidence(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		filename   string
+		wantPath   string
+		wantIsFile bool
+	}{
+		{name: "Dotted Folder", url: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/main/--/v1.2/?region=us-east-1", wantPath: "v1.2", wantIsFile: false},
+		{name: "Filename", url: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/main/--/v1.2?region=us-east-1", filename: "setup.sh", wantPath: "v1.2/setup.sh", wantIsFile: true},
+		{name: "Extension Fallback", url: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/main/--/go.mod?region=us-east-1", wantPath: "go.mod", wantIsFile: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGitRepository("", "", tt.url, "")
+			if err := r.Parse("", DirectionNone, tt.filename); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+			if r.Path != tt.wantPath || r.IsFile != tt.wantIsFile {
+				t.Errorf("GitRepository.Parse() Path = %q, IsFile = %v, want %q, %v", r.Path, r.IsFile, tt.wantPath, tt.wantIsFile)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package gitrepository
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// download option of repository: what to download for the parsed url
+type DownloadType int
+
+// enums: download options
+// values are stable, stored payloads keep numbers, text payloads read numbers too
+const (
+	DownloadNone           DownloadType = -1
+	DownloadFullPackage    DownloadType = 1
+	DownloadPartialPackage DownloadType = 2
+	DownloadSingleFile     DownloadType = 3
+	DownloadCustomPackage  DownloadType = 4
+)
+
+var downloadTypeNames = map[DownloadType]string{
+	DownloadNone:           "none",
+	DownloadFullPackage:    "full_package",
+	DownloadPartialPackage: "partial_package",
+	DownloadSingleFile:     "single_file",
+	DownloadCustomPackage:  "custom_package",
+}
+
+// known download type
+func (t DownloadType) IsValid() bool {
+	_, ok := downloadTypeNames[t]
+	return ok
+}
+
+// full_package, partial_package, single_file...
+func (t DownloadType) String() string {
+	if name, ok := downloadTypeNames[t]; ok {
+		return name
+	}
+
+	return "DownloadType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// json and text payloads read names: "single_file"
+func (t DownloadType) MarshalText() ([]byte, error) {
+	if !t.IsValid() {
+		return nil, fmt.Errorf("not valid download type: %d", t)
+	}
+
+	return []byte(t.String()), nil
+}
+
+// names and numbers of stored payloads, unknown values rejected
+func (t *DownloadType) UnmarshalText(text []byte) error {
+	for downloadType, name := range downloadTypeNames {
+		if name == string(text) {
+			*t = downloadType
+			return nil
+		}
+	}
+	if number, err := strconv.Atoi(string(text)); err == nil && DownloadType(number).IsValid() {
+		*t = DownloadType(number)
+		return nil
+	}
+
+	return fmt.Errorf("not valid download type: %q", text)
+}
+
+// folder jump direction of sub folder parse
+type Direction int
+
+const (
+	DirectionNone Direction = 0
+	DirectionUp   Direction = 1
+	DirectionDown Direction = 2
+)
+
+var directionNames = map[Direction]string{
+	DirectionNone: "none",
+	DirectionUp:   "up",
+	DirectionDown: "down",
+}
+
+// known direction
+func (d Direction) IsValid() bool {
+	_, ok := directionNames[d]
+	return ok
+}
+
+// none, up, down
+func (d Direction) String() string {
+	if name, ok := directionNames[d]; ok {
+		return name
+	}
+
+	return "Direction(" + strconv.Itoa(int(d)) + ")"
+}
+
+// json and text payloads read names: "up"
+func (d Direction) MarshalText() ([]byte, error) {
+	if !d.IsValid() {
+		return nil, fmt.Errorf("not valid direction: %d", d)
+	}
+
+	return []byte(d.String()), nil
+}
+
+// names and numbers of stored payloads, unknown values rejected
+func (d *Direction) UnmarshalText(text []byte) error {
+	for direction, name := range directionNames {
+		if name == string(text) {
+			*d = direction
+			return nil
+		}
+	}
+	if number, err := strconv.Atoi(string(text)); err == nil && Direction(number).IsValid() {
+		*d = Direction(number)
+		return nil
+	}
+
+	return fmt.Errorf("not valid direction: %q", text)
+}
@@ -0,0 +1,138 @@
+package gitrepository
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDownloadType_Text(t *testing.T) {
+	tests := []struct {
+		name         string
+		downloadType DownloadType
+		want         string
+		wantErr      bool
+	}{
+		{name: "None", downloadType: DownloadNone, want: "none"},
+		{name: "Full Package", downloadType: DownloadFullPackage, want: "full_package"},
+		{name: "Partial Package", downloadType: DownloadPartialPackage, want: "partial_package"},
+		{name: "Single File", downloadType: DownloadSingleFile, want: "single_file"},
+		{name: "Custom Package", downloadType: DownloadCustomPackage, want: "custom_package"},
+		{name: "Not Valid", downloadType: DownloadType(0), want: "DownloadType(0)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.downloadType.String(); got != tt.want {
+				t.Errorf("DownloadType.String() = %v, want %v", got, tt.want)
+			}
+
+			text, err := tt.downloadType.MarshalText()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DownloadType.MarshalText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var got DownloadType
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatalf("DownloadType.UnmarshalText() error = %v", err)
+			}
+			if got != tt.downloadType {
+				t.Errorf("DownloadType.UnmarshalText() = %v, want %v", got, tt.downloadType)
+			}
+		})
+	}
+}
+
+func TestDirection_Text(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction Direction
+		want      string
+		wantErr   bool
+	}{
+		{name: "None", direction: DirectionNone, want: "none"},
+		{name: "Up", direction: DirectionUp, want: "up"},
+		{name: "Down", direction: DirectionDown, want: "down"},
+		{name: "Not Valid", direction: Direction(-1), want: "Direction(-1)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.direction.String(); got != tt.want {
+				t.Errorf("Direction.String() = %v, want %v", got, tt.want)
+			}
+
+			text, err := tt.direction.MarshalText()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Direction.MarshalText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var got Direction
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatalf("Direction.UnmarshalText() error = %v", err)
+			}
+			if got != tt.direction {
+				t.Errorf("Direction.UnmarshalText() = %v, want %v", got, tt.direction)
+			}
+		})
+	}
+}
+
+func TestEnums_JSON(t *testing.T) {
+	type payload struct {
+		DownloadType DownloadType `json:"download_type"`
+		Direction    Direction    `json:"direction"`
+	}
+
+	data, err := json.Marshal(payload{DownloadType: DownloadSingleFile, Direction: DirectionUp})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"download_type":"single_file","direction":"up"}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	got := payload{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if want := (payload{DownloadType: DownloadSingleFile, Direction: DirectionUp}); !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal() = %#v, want %#v", got, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"download_type":"3","direction":"2"}`), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if want := (payload{DownloadType: DownloadSingleFile, Direction: DirectionDown}); !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal() = %#v, want %#v", got, want)
+	}
+
+	for _, data := range []string{
+		`{"download_type":"single"}`,
+		`{"download_type":"0"}`,
+		`{"direction":"3"}`,
+		`{"download_type":3}`,
+		`{"direction":"left"}`,
+	} {
+		if err := json.Unmarshal([]byte(data), &payload{}); err == nil {
+			t.Errorf("json.Unmarshal(%s) error = nil, want error", data)
+		}
+	}
+	if _, err := json.Marshal(payload{DownloadType: DownloadType(9)}); err == nil {
+		t.Errorf("json.Marshal() error = nil, want error")
+	}
+}
+
+func TestGitRepository_ParseDirection(t *testing.T) {
+	r := NewGitRepository("", "", "https://github.com/cli/cli/tree/trunk/pkg", "")
+	if err := r.Parse("cmd", Direction(3), ""); err == nil {
+		t.Errorf("GitRepository.Parse() error = nil, want error")
+	}
+	if r.Url != "" || r.DownloadType != DownloadNone {
+		t.Errorf("GitRepository.Parse() changed repository = %#v", r)
+	}
+}
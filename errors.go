@@ -0,0 +1,85 @@
+package gitrepository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// parse errors: compare with errors.Is
+var (
+	ErrUnsupportedHost = errors.New("not supported git host")
+	ErrMissingHost     = errors.New("not valid git url: missing host")
+	ErrMissingOwner    = errors.New("not valid git url: missing owner")
+	ErrMissingRepo     = errors.New("not valid git url: missing repository")
+	ErrMissingRegion   = errors.New("not valid git url: missing region")
+	ErrUnknownRoute    = errors.New("not valid git url: unknown route")
+	ErrInvalidRef      = errors.New("not valid git branch")
+	ErrAmbiguousRef    = errors.New("not valid git branch: ambiguous ref")
+)
+
+// parse error of url, find it with errors.As
+// segment is the wrong part of input, empty if the part is missing
+// offset is the byte offset of segment in input, the end of input if segment is missing
+type ParseError struct {
+	Input   string
+	Segment string
+	Offset  int
+	Err     error
+}
+
+// route parsers set segment, parse sets input and offset
+func newParseError(err error, segment string) *ParseError {
+	return &ParseError{Segment: segment, Offset: -1, Err: err}
+}
+
+func (e *ParseError) Error() string {
+	switch {
+	case e.Offset < 0:
+		return e.Err.Error()
+	case e.Segment == "":
+		return fmt.Sprintf("%s at offset %d", e.Err, e.Offset)
+	}
+
+	return fmt.Sprintf("%s: %q at offset %d", e.Err, e.Segment, e.Offset)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// wrap error with input, errors without segment wrapped too
+func wrapParseError(err error, input, hostname string) *ParseError {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		parseErr = newParseError(err, "")
+	}
+
+	parseErr.Input = input
+	parseErr.Offset = segmentOffset(input, hostname, parseErr.Segment)
+
+	return parseErr
+}
+
+// find offset of segment in input, search starts after hostname
+// missing segments point the end of input
+func segmentOffset(input, hostname, segment string) int {
+	if segment == "" {
+		return len(input)
+	}
+
+	start := 0
+	if hostname != "" {
+		if index := strings.Index(input, hostname); index != -1 {
+			start = index + len(hostname)
+		}
+	}
+	if index := strings.Index(input[start:], segment); index != -1 {
+		return start + index
+	}
+	if index := strings.Index(input, segment); index != -1 {
+		return index
+	}
+
+	return len(input)
+}
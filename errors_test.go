@@ -0,0 +1,135 @@
+package gitrepository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseURL_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		opts        []Option
+		wantErr     error
+		wantSegment string
+		wantOffset  int
+	}{
+		{
+			name:        "Parse Missing Repository",
+			url:         "https://github.com/cli",
+			wantErr:     ErrMissingRepo,
+			wantSegment: "",
+			wantOffset:  22,
+		},
+		{
+			name:        "Parse Missing Owner",
+			url:         "https://git.sr.ht/sircmpwn/scdoc",
+			wantErr:     ErrMissingOwner,
+			wantSegment: "sircmpwn",
+			wantOffset:  18,
+		},
+		{
+			name:        "Parse Empty Repository",
+			url:         "https://github.com/cli/.git",
+			wantErr:     ErrMissingRepo,
+			wantSegment: "",
+			wantOffset:  27,
+		},
+		{
+			name:        "Parse Gitea Empty Repository",
+			url:         "https://codeberg.org/cli/.git",
+			wantErr:     ErrMissingRepo,
+			wantSegment: "",
+			wantOffset:  29,
+		},
+		{
+			name:        "Parse Empty Owner",
+			url:         "https://github.com//cli",
+			wantErr:     ErrMissingOwner,
+			wantSegment: "",
+			wantOffset:  23,
+		},
+		{
+			name:        "Parse Codecommit Missing Region",
+			url:         "https://git-codecommit.amazonaws.com/v1/repos/my-repo",
+			wantErr:     ErrMissingRegion,
+			wantSegment: "",
+			wantOffset:  53,
+		},
+		{
+			name:        "Parse Unknown Route",
+			url:         "https://github.com/cli/cli/commits/trunk",
+			wantErr:     ErrUnknownRoute,
+			wantSegment: "commits",
+			wantOffset:  27,
+		},
+		{
+			name:        "Parse Gitea Unknown Route",
+			url:         "https://gitea.com/gitea/tea/wiki",
+			wantErr:     ErrUnknownRoute,
+			wantSegment: "wiki",
+			wantOffset:  28,
+		},
+		{
+			name:        "Parse Invalid Ref",
+			url:         "https://dev.azure.com/org/project/_git/repo?version=XXmain",
+			wantErr:     ErrInvalidRef,
+			wantSegment: "XXmain",
+			wantOffset:  52,
+		},
+		{
+			name:        "Parse Cgit Invalid Commit",
+			url:         "https://git.kernel.org/pub/scm/git/git.git/tree/README.md?h=master&id=HEAD~1",
+			wantErr:     ErrInvalidRef,
+			wantSegment: "HEAD~1",
+			wantOffset:  70,
+		},
+		{
+			name:        "Parse Ambiguous Ref",
+			url:         "https://github.com/cli/cli/tree/feature/x/api",
+			opts:        []Option{BranchHint("release/v2")},
+			wantErr:     ErrAmbiguousRef,
+			wantSegment: "feature",
+			wantOffset:  32,
+		},
+		{
+			name:        "Parse Missing Host",
+			url:         "  github.com/cli/cli  ",
+			wantErr:     ErrMissingHost,
+			wantSegment: "",
+			wantOffset:  22,
+		},
+		{
+			name:        "Parse Strict Unsupported Host",
+			url:         "https://git.example.io/owner/repo",
+			opts:        []Option{Strict()},
+			wantErr:     ErrUnsupportedHost,
+			wantSegment: "git.example.io",
+			wantOffset:  8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseURL(tt.url, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseURL() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseURL() error = %#v, want *ParseError", err)
+			}
+			if parseErr.Input != tt.url || parseErr.Segment != tt.wantSegment || parseErr.Offset != tt.wantOffset {
+				t.Errorf("ParseError = %#v, want segment %q offset %d", parseErr, tt.wantSegment, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	_, err := ParseURL("https://github.com/cli/cli/commits/trunk")
+	want := `not valid git url: unknown route: "commits" at offset 27`
+	if err == nil || err.Error() != want {
+		t.Errorf("ParseError.Error() = %v, want %v", err, want)
+	}
+}
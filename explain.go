@@ -0,0 +1,253 @@
+package gitrepository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// explanation of a parse: rules applied in order and evidence of output fields
+// render as text with String, as json with JSON or encoding/json
+type Explanation struct {
+	Input  string          `json:"input"`
+	Steps  []ExplainStep   `json:"steps"`
+	Fields []FieldEvidence `json:"fields"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// rule applied while parsing
+type ExplainStep struct {
+	Rule     string   `json:"rule"`               // heuristic name: host match, branch split...
+	Decision string   `json:"decision"`           // what the rule decided
+	Evidence string   `json:"evidence,omitempty"` // what in the url made the decision
+	Rejected []string `json:"rejected,omitempty"` // alternatives the rule did not pick
+	Fields   []string `json:"fields,omitempty"`   // output fields the rule set
+}
+
+// output field and the last rule which set it
+type FieldEvidence struct {
+	Field    string `json:"field"`
+	Value    string `json:"value"`
+	Rule     string `json:"rule"`
+	Evidence string `json:"evidence,omitempty"`
+}
+
+// explained output fields, in order
+var explainFields = []string{"protocol", "scheme", "hostname", "forge", "owner", "name", "branch", "ref_kind", "path", "is_file", "download_type"}
+
+// steps of a parse, location collects them only while explaining
+type explainTrace struct {
+	steps []ExplainStep
+}
+
+// explain parse of git url: which rules fired and why
+/*
+explanation, err := Explain("https://gitlab.com/a/b/c/-/tree/main/docs")
+fmt.Println(explanation) // text
+json.Marshal(explanation) // json
+*/
+func Explain(raw string, opts ...Option) (Explanation, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.trace = &explainTrace{}
+
+	l, err := parseLocation(raw, o)
+
+	// explanations are printed and shared, secrets of url stay out
+	explanation := Explanation{
+		Input:  redactUrl(raw),
+		Steps:  o.trace.steps,
+		Fields: o.trace.fieldEvidences(&l),
+	}
+	if err != nil {
+		explanation.Error = err.Error()
+	}
+
+	return explanation, err
+}
+
+// render explanation as text lines
+func (e Explanation) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "input: %s\n", e.Input)
+	for i, step := range e.Steps {
+		fmt.Fprintf(b, "%d. %s: %s\n", i+1, step.Rule, step.Decision)
+		if step.Evidence != "" {
+			fmt.Fprintf(b, "   evidence: %s\n", step.Evidence)
+		}
+		if len(step.Rejected) > 0 {
+			fmt.Fprintf(b, "   rejected: %s\n", strings.Join(step.Rejected, "; "))
+		}
+	}
+	if len(e.Fields) > 0 {
+		b.WriteString("fields:\n")
+		for _, field := range e.Fields {
+			fmt.Fprintf(b, "   %s = %q <- %s\n", field.Field, field.Value, field.Rule)
+		}
+	}
+	if e.Error != "" {
+		fmt.Fprintf(b, "error: %s\n", e.Error)
+	}
+
+	return b.String()
+}
+
+// render explanation as indented json
+func (e Explanation) JSON() ([]byte, error) {
+	return json.MarshalIndent(e, "", "  ")
+}
+
+// location collects steps
+func (l *Location) isExplaining() bool {
+	return l.trace != nil
+}
+
+// add step to trace, check isExplaining before building steps
+func (l *Location) explain(step ExplainStep) {
+	l.trace.steps = append(l.trace.steps, step)
+}
+
+// fields already set by a step
+func (t *explainTrace) explained(field string) bool {
+	for _, step := range t.steps {
+		if slices.Contains(step.Fields, field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// last step of each field, fields no rule set are skipped
+func (t *explainTrace) fieldEvidences(l *Location) []FieldEvidence {
+	evidences := []FieldEvidence{}
+	for _, field := range explainFields {
+		for i := len(t.steps) - 1; i >= 0; i-- {
+			if !slices.Contains(t.steps[i].Fields, field) {
+				continue
+			}
+			evidences = append(evidences, FieldEvidence{
+				Field:    field,
+				Value:    l.explainValue(field),
+				Rule:     t.steps[i].Rule,
+				Evidence: t.steps[i].Evidence,
+			})
+			break
+		}
+	}
+
+	return evidences
+}
+
+// value of explained field
+func (l *Location) explainValue(field string) string {
+	switch field {
+	case "protocol":
+		return l.Protocol
+	case "scheme":
+		return l.Scheme
+	case "hostname":
+		return l.Hostname
+	case "forge":
+		return l.Forge
+	case "owner":
+		return l.Owner
+	case "name":
+		return l.Name
+	case "branch":
+		return l.Branch
+	case "ref_kind":
+		return l.RefKind
+	case "path":
+		return l.Path
+	case "is_file":
+		return strconv.FormatBool(l.IsFile)
+	case "download_type":
+		return l.DownloadType.String()
+	}
+
+	return ""
+}
+
+// explain route parse of provider, fields no route rule explained belong to provider
+func (l *Location) explainRoute(provider Provider, err error) {
+	step := ExplainStep{Rule: "route parse", Decision: "forge " + strconv.Quote(provider.Forge()) + " routes", Evidence: "path " + l.RawPath}
+	if err != nil {
+		step.Decision = "failed: " + err.Error()
+	}
+	for _, field := range []string{"owner", "name", "branch", "ref_kind", "path", "is_file"} {
+		if !l.trace.explained(field) {
+			step.Fields = append(step.Fields, field)
+		}
+	}
+
+	l.explain(step)
+}
+
+// explain download type, rules checked in order: clone url, empty path, file path, folder path
+func (l *Location) explainDownloadType(reason string) {
+	rules := []string{"clone url", "empty path", "file path", "folder path"}
+	evidences := map[string]string{
+		"clone url":   "clone url is browse url + .git",
+		"empty path":  "path is empty, whole repository",
+		"file path":   "path is a file",
+		"folder path": "path is a folder",
+	}
+	index := slices.Index(rules, reason)
+	l.explain(ExplainStep{
+		Rule:     "download type",
+		Decision: l.DownloadType.String(),
+		Evidence: evidences[reason],
+		Rejected: rules[:index],
+		Fields:   []string{"download_type"},
+	})
+}
+
+// explain provider match of url: registered hostname, registered or built-in provider, generic
+// providers checked before the match are rejected
+func explainProviderMatch(u *url.URL, provider Provider) ExplainStep {
+	step := ExplainStep{
+		Rule:     "host match",
+		Decision: "forge " + strconv.Quote(provider.Forge()),
+		Fields:   []string{"hostname", "forge"},
+	}
+
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	if forge, ok := forgeHosts[strings.ToLower(u.Hostname())]; ok {
+		step.Evidence = "hostname registered with forge " + strconv.Quote(forge)
+		if forge != provider.Forge() {
+			step.Evidence += ", url routes decide " + strconv.Quote(provider.Forge())
+		}
+		return step
+	}
+
+	// match order of findProvider: last registered provider first
+	checked := []Provider{}
+	for i := len(registeredProviders) - 1; i >= 0; i-- {
+		checked = append(checked, registeredProviders[i])
+	}
+	checked = append(checked, builtinProviders...)
+	for i, candidate := range checked {
+		if !candidate.Match(u) {
+			step.Rejected = append(step.Rejected, candidate.Forge())
+			continue
+		}
+		if i < len(registeredProviders) {
+			step.Evidence = "registered provider matches " + u.Hostname()
+		} else {
+			step.Evidence = "built-in provider matches " + u.Hostname()
+		}
+		return step
+	}
+
+	step.Evidence = "no provider matches " + u.Hostname() + ", generic owner/name routes"
+
+	return step
+}
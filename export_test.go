@@ -0,0 +1,24 @@
+package gitrepository
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// registry of providers and forge hosts comes back after test
+func restoreRegistry(t testing.TB) {
+	providersMu.RLock()
+	providers, hosts := slices.Clone(registeredProviders), maps.Clone(forgeHosts)
+	providersMu.RUnlock()
+
+	t.Cleanup(func() {
+		providersMu.Lock()
+		defer providersMu.Unlock()
+
+		registeredProviders, forgeHosts = providers, hosts
+	})
+}
+
+// helpers of external tests
+var RestoreRegistry = restoreRegistry
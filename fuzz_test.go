@@ -0,0 +1,135 @@
+package gitrepository
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// fuzz seed of table tests
+type fuzzSeed struct {
+	url, branch, sub, filename string
+}
+
+// seed corpus: url, branch, sub and filename of all table tests of this package
+func tableTestSeeds(t testing.TB) []fuzzSeed {
+	t.Helper()
+
+	files, err := filepath.Glob("*_test.go")
+	if err != nil {
+		t.Fatalf("filepath.Glob() error = %v", err)
+	}
+
+	seeds := []fuzzSeed{}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatalf("parser.ParseFile(%s) error = %v", file, err)
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			lit, ok := n.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+
+			fields := map[string]string{}
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				value, isString := kv.Value.(*ast.BasicLit)
+				if !ok || !isString || value.Kind != token.STRING {
+					continue
+				}
+				if s, err := strconv.Unquote(value.Value); err == nil {
+					fields[key.Name] = s
+				}
+			}
+			if url, ok := fields["url"]; ok {
+				seeds = append(seeds, fuzzSeed{url: url, branch: fields["branch"], sub: fields["sub"], filename: fields["filename"]})
+			}
+
+			return true
+		})
+	}
+
+	return seeds
+}
+
+// inputs which panicked before
+var fuzzPanicSeeds = []fuzzSeed{
+	{url: "https://gitea.com/a/b/src"},
+	{url: "https://gitlab.com/a/b/c/tree"},
+	{url: "https://github.com/a/b/tree/x", branch: "x/y/z/w"},
+	{url: "https://github.com/a/b/tree/x/y", branch: "x/y/z/w", sub: "y"},
+	{url: "https://github.com/a/b", sub: "root"},
+	{url: "git@:", branch: "/"},
+	{url: "https://github.com/", filename: "/"},
+	{url: "@githuB.Com:0/.Git"},
+	{url: "A://0/0/0/src"},
+}
+
+func FuzzParseURL(f *testing.F) {
+	for _, seed := range append(tableTestSeeds(f), fuzzPanicSeeds...) {
+		f.Add(seed.url, seed.branch, seed.filename, uint8(0))
+	}
+
+	f.Fuzz(func(t *testing.T, raw, branch, filename string, mode uint8) {
+		opts := []Option{BranchHint(branch), Filename(filename)}
+		switch mode % 3 {
+		case 1:
+			opts = append(opts, Strict())
+		case 2:
+			opts = append(opts, Lenient())
+		}
+
+		l, err := ParseURL(raw, opts...)
+		if err != nil {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseURL(%q) error = %#v, want *ParseError", raw, err)
+			}
+			if l != (Location{}) {
+				t.Fatalf("ParseURL(%q) = %#v, want empty location on error", raw, l)
+			}
+			return
+		}
+		if l.RawUrl != raw {
+			t.Fatalf("ParseURL(%q) RawUrl = %q", raw, l.RawUrl)
+		}
+
+		if _, explainErr := Explain(raw, opts...); (explainErr != nil) != (err != nil) {
+			t.Fatalf("Explain(%q) error = %v, ParseURL error = %v", raw, explainErr, err)
+		}
+		if n := l.Normalize(); !reflect.DeepEqual(n.Normalize(), n) {
+			t.Fatalf("Normalize(%q) = %#v, not idempotent %#v", raw, n, n.Normalize())
+		}
+	})
+}
+
+func FuzzGitRepository_Parse(f *testing.F) {
+	for _, seed := range append(tableTestSeeds(f), fuzzPanicSeeds...) {
+		f.Add(seed.url, seed.branch, seed.sub, seed.filename, int(DirectionNone))
+		if seed.sub != "" {
+			f.Add(seed.url, seed.branch, seed.sub, seed.filename, int(DirectionUp))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, raw, branch, sub, filename string, direction int) {
+		r := NewGitRepository("", "", raw, branch)
+		if err := r.Parse(sub, Direction(direction), filename); err == nil {
+			r.UpdateBranch(branch)
+			r.GetQueryUrl(sub)
+			r.WithoutCloneUrl()
+		}
+	})
+}
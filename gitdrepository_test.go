@@ -27,11 +27,12 @@ func TestGitRepository_GithubParse(t *testing.T) {
 				CloneUrl:     "https://github.com/cli/cli.git",
 				RemoteUrl:    "git@github.com:cli/cli.git",
 				QueryUrl:     "https://github.com/cli/cli",
-				DirPath:      "repository/cli/cli/gitd-branch",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", Ref: "gitd-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
 				RawPath:      "/cli/cli",
 				Path:         "",
 				Owner:        "cli",
@@ -57,17 +58,19 @@ func TestGitRepository_GithubParse(t *testing.T) {
 				CloneUrl:     "https://github.com/cli/cli.git",
 				RemoteUrl:    "git@github.com:cli/cli.git",
 				QueryUrl:     "https://github.com/cli/cli/tree/bad-branch/",
-				DirPath:      "repository/cli/cli/bad-branch",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "bad-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
 				RawPath:      "/cli/cli/tree/bad-branch",
 				Path:         "",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "bad-branch",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/bad-branch.zip",
 				FileUrl:      "https://raw.githubusercontent.com/cli/cli/bad-branch/[PATH]",
 				DownloadType: DownloadFullPackage,
@@ -87,17 +90,19 @@ func TestGitRepository_GithubParse(t *testing.T) {
 				CloneUrl:     "https://github.com/cli/cli.git",
 				RemoteUrl:    "git@github.com:cli/cli.git",
 				QueryUrl:     "https://github.com/cli/cli/tree/bad-branch/cmd/",
-				DirPath:      "repository/cli/cli/bad-branch",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "bad-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
 				RawPath:      "/cli/cli/tree/bad-branch/cmd",
 				Path:         "cmd",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "bad-branch",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/bad-branch.zip",
 				FileUrl:      "https://raw.githubusercontent.com/cli/cli/bad-branch/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -117,17 +122,19 @@ func TestGitRepository_GithubParse(t *testing.T) {
 				CloneUrl:     "https://github.com/cli/cli.git",
 				RemoteUrl:    "git@github.com:cli/cli.git",
 				QueryUrl:     "https://github.com/cli/cli/tree/bad-branch/cmd/gh/",
-				DirPath:      "repository/cli/cli/bad-branch",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "bad-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
 				RawPath:      "/cli/cli/tree/bad-branch/cmd/gh",
 				Path:         "cmd/gh",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "bad-branch",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/bad-branch.zip",
 				FileUrl:      "https://raw.githubusercontent.com/cli/cli/bad-branch/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -147,17 +154,19 @@ func TestGitRepository_GithubParse(t *testing.T) {
 				CloneUrl:     "https://github.com/cli/cli.git",
 				RemoteUrl:    "git@github.com:cli/cli.git",
 				QueryUrl:     "https://github.com/cli/cli/tree/develop/services/website-constellation/",
-				DirPath:      "repository/cli/cli/develop",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "develop"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
 				RawPath:      "/cli/cli/blob/develop/services/website-constellation/gatsby-node.js",
 				Path:         "services/website-constellation/gatsby-node.js",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "develop",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/develop.zip",
 				FileUrl:      "https://raw.githubusercontent.com/cli/cli/develop/[PATH]",
 				DownloadType: DownloadSingleFile,
@@ -177,17 +186,19 @@ func TestGitRepository_GithubParse(t *testing.T) {
 				CloneUrl:     "https://github.com/cli/cli.git",
 				RemoteUrl:    "git@github.com:cli/cli.git",
 				QueryUrl:     "https://github.com/cli/cli/tree/ckharrl/CONCLOUD-68878-close-manager-propagation/",
-				DirPath:      "repository/cli/cli/ckharrl/CONCLOUD-68878-close-manager-propagation",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "ckharrl/CONCLOUD-68878-close-manager-propagation"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
 				RawPath:      "/cli/cli/tree/ckharrl/CONCLOUD-68878-close-manager-propagation",
 				Path:         "",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "ckharrl/CONCLOUD-68878-close-manager-propagation",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/ckharrl/CONCLOUD-68878-close-manager-propagation.zip",
 				FileUrl:      "https://raw.githubusercontent.com/cli/cli/ckharrl/CONCLOUD-68878-close-manager-propagation/[PATH]",
 				DownloadType: DownloadFullPackage,
@@ -207,17 +218,19 @@ func TestGitRepository_GithubParse(t *testing.T) {
 				CloneUrl:     "https://github.com/cli/cli.git",
 				RemoteUrl:    "git@github.com:cli/cli.git",
 				QueryUrl:     "https://github.com/cli/cli/tree/trunk/",
-				DirPath:      "repository/cli/cli/trunk",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "trunk"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
 				RawPath:      "/cli/cli/blob/trunk/Makefile",
 				Path:         "Makefile",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "trunk",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/trunk.zip",
 				FileUrl:      "https://raw.githubusercontent.com/cli/cli/trunk/[PATH]",
 				DownloadType: DownloadSingleFile,
@@ -265,17 +278,19 @@ func TestGitRepository_GithubParse(t *testing.T) {
 				CloneUrl:     "https://github.com/cli/cli.git",
 				RemoteUrl:    "git@github.com:cli/cli.git",
 				QueryUrl:     "https://github.com/cli/cli/tree/ckharrl/CONCLOUD-68878-close-manager-propagation/services/website-constellation/",
-				DirPath:      "repository/cli/cli/ckharrl/CONCLOUD-68878-close-manager-propagation",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "ckharrl/CONCLOUD-68878-close-manager-propagation"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
 				RawPath:      "/cli/cli/blob/ckharrl/CONCLOUD-68878-close-manager-propagation/services/website-constellation/gatsby-node.js",
 				Path:         "services/website-constellation/gatsby-node.js",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "ckharrl/CONCLOUD-68878-close-manager-propagation",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/ckharrl/CONCLOUD-68878-close-manager-propagation.zip",
 				FileUrl:      "https://raw.githubusercontent.com/cli/cli/ckharrl/CONCLOUD-68878-close-manager-propagation/[PATH]",
 				DownloadType: DownloadSingleFile,
@@ -295,11 +310,43 @@ func TestGitRepository_GithubParse(t *testing.T) {
 				CloneUrl:     "https://github.com/cli/cli.git",
 				RemoteUrl:    "git@github.com:cli/cli.git",
 				QueryUrl:     "https://github.com/cli/cli",
-				DirPath:      "repository/cli/cli/gitd-branch",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", Ref: "gitd-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
+				RawPath:      "/cli/cli",
+				Path:         "",
+				Owner:        "cli",
+				Name:         "cli",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/.zip",
+				FileUrl:      "https://raw.githubusercontent.com/cli/cli//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Github SCP-Style Remote Url",
+			url:    "git@github.com:cli/cli.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://github.com/cli/cli",
+				RawUrl:       "git@github.com:cli/cli.git",
+				CloneUrl:     "https://github.com/cli/cli.git",
+				RemoteUrl:    "git@github.com:cli/cli.git",
+				QueryUrl:     "https://github.com/cli/cli",
+				DirPath:      keyDirPath(Key{Hostname: "github.com", Namespace: "cli", Name: "cli", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
 				RawPath:      "/cli/cli",
 				Path:         "",
 				Owner:        "cli",
@@ -369,11 +416,12 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
 				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
 				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab",
-				DirPath:      "repository/gitlab-org/gitlab/gitd-branch",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "gitlab-org", Name: "gitlab", Ref: "gitd-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/gitlab-org/gitlab",
 				Path:         "",
 				Owner:        "gitlab-org",
@@ -399,17 +447,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
 				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
 				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab/tree/dc-move-assignees-widget/",
-				DirPath:      "repository/gitlab-org/gitlab/dc-move-assignees-widget",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "gitlab-org", Name: "gitlab", RefKind: RefBranch, Ref: "dc-move-assignees-widget"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/gitlab-org/gitlab/tree/dc-move-assignees-widget",
 				Path:         "",
 				Owner:        "gitlab-org",
 				Name:         "gitlab",
 				DummyBranch:  "gitd-branch",
 				Branch:       "dc-move-assignees-widget",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/gitlab-org/gitlab/-/archive/dc-move-assignees-widget/gitlab-dc-move-assignees-widget.zip",
 				FileUrl:      "https://gitlab.com/gitlab-org/gitlab/-/raw/dc-move-assignees-widget/[PATH]",
 				DownloadType: DownloadFullPackage,
@@ -429,17 +479,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
 				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
 				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab/tree/dc-move-assignees-widget/metrics_server/",
-				DirPath:      "repository/gitlab-org/gitlab/dc-move-assignees-widget",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "gitlab-org", Name: "gitlab", RefKind: RefBranch, Ref: "dc-move-assignees-widget"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/gitlab-org/gitlab/tree/dc-move-assignees-widget/metrics_server",
 				Path:         "metrics_server",
 				Owner:        "gitlab-org",
 				Name:         "gitlab",
 				DummyBranch:  "gitd-branch",
 				Branch:       "dc-move-assignees-widget",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/gitlab-org/gitlab/-/archive/dc-move-assignees-widget/gitlab-dc-move-assignees-widget.zip",
 				FileUrl:      "https://gitlab.com/gitlab-org/gitlab/-/raw/dc-move-assignees-widget/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -459,17 +511,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
 				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
 				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab/tree/dc-move-assignees-widget/db/fixtures/",
-				DirPath:      "repository/gitlab-org/gitlab/dc-move-assignees-widget",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "gitlab-org", Name: "gitlab", RefKind: RefBranch, Ref: "dc-move-assignees-widget"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/gitlab-org/gitlab/tree/dc-move-assignees-widget/db/fixtures",
 				Path:         "db/fixtures",
 				Owner:        "gitlab-org",
 				Name:         "gitlab",
 				DummyBranch:  "gitd-branch",
 				Branch:       "dc-move-assignees-widget",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/gitlab-org/gitlab/-/archive/dc-move-assignees-widget/gitlab-dc-move-assignees-widget.zip",
 				FileUrl:      "https://gitlab.com/gitlab-org/gitlab/-/raw/dc-move-assignees-widget/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -489,17 +543,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
 				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
 				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab/tree/dc-move-assignees-widget/db/migrate/",
-				DirPath:      "repository/gitlab-org/gitlab/dc-move-assignees-widget",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "gitlab-org", Name: "gitlab", RefKind: RefBranch, Ref: "dc-move-assignees-widget"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/gitlab-org/gitlab/blob/dc-move-assignees-widget/db/migrate/20210301200959_init_schema.rb",
 				Path:         "db/migrate/20210301200959_init_schema.rb",
 				Owner:        "gitlab-org",
 				Name:         "gitlab",
 				DummyBranch:  "gitd-branch",
 				Branch:       "dc-move-assignees-widget",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/gitlab-org/gitlab/-/archive/dc-move-assignees-widget/gitlab-dc-move-assignees-widget.zip",
 				FileUrl:      "https://gitlab.com/gitlab-org/gitlab/-/raw/dc-move-assignees-widget/[PATH]",
 				DownloadType: DownloadSingleFile,
@@ -519,17 +575,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
 				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
 				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab/tree/ss/add-community-docs/",
-				DirPath:      "repository/gitlab-org/gitlab/ss/add-community-docs",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "gitlab-org", Name: "gitlab", RefKind: RefBranch, Ref: "ss/add-community-docs"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/gitlab-org/gitlab/tree/ss/add-community-docs",
 				Path:         "",
 				Owner:        "gitlab-org",
 				Name:         "gitlab",
 				DummyBranch:  "gitd-branch",
 				Branch:       "ss/add-community-docs",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/gitlab-org/gitlab/-/archive/ss/add-community-docs/gitlab-ss-add-community-docs.zip",
 				FileUrl:      "https://gitlab.com/gitlab-org/gitlab/-/raw/ss/add-community-docs/[PATH]",
 				DownloadType: DownloadFullPackage,
@@ -549,17 +607,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
 				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
 				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab/tree/master/",
-				DirPath:      "repository/gitlab-org/gitlab/master",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "gitlab-org", Name: "gitlab", RefKind: RefBranch, Ref: "master"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/gitlab-org/gitlab/blob/master/Dangerfile",
 				Path:         "Dangerfile",
 				Owner:        "gitlab-org",
 				Name:         "gitlab",
 				DummyBranch:  "gitd-branch",
 				Branch:       "master",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/gitlab-org/gitlab/-/archive/master/gitlab-master.zip",
 				FileUrl:      "https://gitlab.com/gitlab-org/gitlab/-/raw/master/[PATH]",
 				DownloadType: DownloadSingleFile,
@@ -607,17 +667,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
 				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
 				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab/tree/ss/add-community-docs/app/mailers/",
-				DirPath:      "repository/gitlab-org/gitlab/ss/add-community-docs",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "gitlab-org", Name: "gitlab", RefKind: RefBranch, Ref: "ss/add-community-docs"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/gitlab-org/gitlab/blob/ss/add-community-docs/app/mailers/abuse_report_mailer.rb",
 				Path:         "app/mailers/abuse_report_mailer.rb",
 				Owner:        "gitlab-org",
 				Name:         "gitlab",
 				DummyBranch:  "gitd-branch",
 				Branch:       "ss/add-community-docs",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/gitlab-org/gitlab/-/archive/ss/add-community-docs/gitlab-ss-add-community-docs.zip",
 				FileUrl:      "https://gitlab.com/gitlab-org/gitlab/-/raw/ss/add-community-docs/[PATH]",
 				DownloadType: DownloadSingleFile,
@@ -637,11 +699,12 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
 				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
 				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab",
-				DirPath:      "repository/gitlab-org/gitlab/gitd-branch",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "gitlab-org", Name: "gitlab", Ref: "gitd-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/gitlab-org/gitlab",
 				Path:         "",
 				Owner:        "gitlab-org",
@@ -667,17 +730,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				RemoteUrl:    "git@gitlab.com:era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				QueryUrl:     "https://gitlab.com/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials/",
-				DirPath:      "repository/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/main",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025", Name: "practical-data-consumption-workshop", RefKind: RefBranch, Ref: "main"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials",
 				Path:         "materials",
 				Owner:        "era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025",
 				Name:         "practical-data-consumption-workshop",
 				DummyBranch:  "gitd-branch",
 				Branch:       "main",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/archive/main/gitlab-main.zip",
 				FileUrl:      "https://gitlab.com/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/raw/main/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -697,17 +762,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/era-europa-eu/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				RemoteUrl:    "git@gitlab.com:era-europa-eu/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				QueryUrl:     "https://gitlab.com/era-europa-eu/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials/",
-				DirPath:      "repository/era-europa-eu/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/main",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "era-europa-eu/interoperable-data-programme/era-ontology/rail-data-forum-2025", Name: "practical-data-consumption-workshop", RefKind: RefBranch, Ref: "main"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/era-europa-eu/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials",
 				Path:         "materials",
 				Owner:        "era-europa-eu/interoperable-data-programme/era-ontology/rail-data-forum-2025",
 				Name:         "practical-data-consumption-workshop",
 				DummyBranch:  "gitd-branch",
 				Branch:       "main",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/era-europa-eu/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/archive/main/gitlab-main.zip",
 				FileUrl:      "https://gitlab.com/era-europa-eu/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/raw/main/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -727,17 +794,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				RemoteUrl:    "git@gitlab.com:interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				QueryUrl:     "https://gitlab.com/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials/",
-				DirPath:      "repository/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/main",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "interoperable-data-programme/era-ontology/rail-data-forum-2025", Name: "practical-data-consumption-workshop", RefKind: RefBranch, Ref: "main"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials",
 				Path:         "materials",
 				Owner:        "interoperable-data-programme/era-ontology/rail-data-forum-2025",
 				Name:         "practical-data-consumption-workshop",
 				DummyBranch:  "gitd-branch",
 				Branch:       "main",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/archive/main/gitlab-main.zip",
 				FileUrl:      "https://gitlab.com/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/raw/main/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -757,17 +826,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				RemoteUrl:    "git@gitlab.com:era-ontology/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				QueryUrl:     "https://gitlab.com/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials/",
-				DirPath:      "repository/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/main",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "era-ontology/rail-data-forum-2025", Name: "practical-data-consumption-workshop", RefKind: RefBranch, Ref: "main"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials",
 				Path:         "materials",
 				Owner:        "era-ontology/rail-data-forum-2025",
 				Name:         "practical-data-consumption-workshop",
 				DummyBranch:  "gitd-branch",
 				Branch:       "main",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/archive/main/gitlab-main.zip",
 				FileUrl:      "https://gitlab.com/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/raw/main/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -787,17 +858,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				RemoteUrl:    "git@gitlab.com:rail-data-forum-2025/practical-data-consumption-workshop.git",
 				QueryUrl:     "https://gitlab.com/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials/",
-				DirPath:      "repository/rail-data-forum-2025/practical-data-consumption-workshop/main",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "rail-data-forum-2025", Name: "practical-data-consumption-workshop", RefKind: RefBranch, Ref: "main"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials",
 				Path:         "materials",
 				Owner:        "rail-data-forum-2025",
 				Name:         "practical-data-consumption-workshop",
 				DummyBranch:  "gitd-branch",
 				Branch:       "main",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/rail-data-forum-2025/practical-data-consumption-workshop/-/archive/main/gitlab-main.zip",
 				FileUrl:      "https://gitlab.com/rail-data-forum-2025/practical-data-consumption-workshop/-/raw/main/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -817,17 +890,19 @@ func TestGitRepository_GitlabParse(t *testing.T) {
 				CloneUrl:     "https://gitlab.com/rail-data-forum-2025/practical-data-consumption-workshop.git",
 				RemoteUrl:    "git@gitlab.com:rail-data-forum-2025/practical-data-consumption-workshop.git",
 				QueryUrl:     "https://gitlab.com/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials/onh/",
-				DirPath:      "repository/rail-data-forum-2025/practical-data-consumption-workshop/main",
+				DirPath:      keyDirPath(Key{Hostname: "gitlab.com", Namespace: "rail-data-forum-2025", Name: "practical-data-consumption-workshop", RefKind: RefBranch, Ref: "main"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
 				RawPath:      "/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials/onh",
 				Path:         "materials/onh",
 				Owner:        "rail-data-forum-2025",
 				Name:         "practical-data-consumption-workshop",
 				DummyBranch:  "gitd-branch",
 				Branch:       "main",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://gitlab.com/rail-data-forum-2025/practical-data-consumption-workshop/-/archive/main/gitlab-main.zip",
 				FileUrl:      "https://gitlab.com/rail-data-forum-2025/practical-data-consumption-workshop/-/raw/main/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -892,11 +967,12 @@ func TestGitRepository_BitbucketParse(t *testing.T) {
 				CloneUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2.git",
 				RemoteUrl:    "git@bitbucket.org:atlassian/atlaskit-mk-2.git",
 				QueryUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2",
-				DirPath:      "repository/atlassian/atlaskit-mk-2/gitd-branch",
+				DirPath:      keyDirPath(Key{Hostname: "bitbucket.org", Namespace: "atlassian", Name: "atlaskit-mk-2", Ref: "gitd-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "bitbucket.org",
+				Forge:        ForgeBitbucket,
 				RawPath:      "/atlassian/atlaskit-mk-2",
 				Path:         "",
 				Owner:        "atlassian",
@@ -922,17 +998,19 @@ func TestGitRepository_BitbucketParse(t *testing.T) {
 				CloneUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2.git",
 				RemoteUrl:    "git@bitbucket.org:atlassian/atlaskit-mk-2.git",
 				QueryUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2/src/develop/",
-				DirPath:      "repository/atlassian/atlaskit-mk-2/develop",
+				DirPath:      keyDirPath(Key{Hostname: "bitbucket.org", Namespace: "atlassian", Name: "atlaskit-mk-2", RefKind: RefBranch, Ref: "develop"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "bitbucket.org",
+				Forge:        ForgeBitbucket,
 				RawPath:      "/atlassian/atlaskit-mk-2/src/develop",
 				Path:         "",
 				Owner:        "atlassian",
 				Name:         "atlaskit-mk-2",
 				DummyBranch:  "gitd-branch",
 				Branch:       "develop",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://bitbucket.org/atlassian/atlaskit-mk-2/get/develop.zip",
 				FileUrl:      "https://bitbucket.org/atlassian/atlaskit-mk-2/raw/develop/[PATH]",
 				DownloadType: DownloadFullPackage,
@@ -952,17 +1030,19 @@ func TestGitRepository_BitbucketParse(t *testing.T) {
 				CloneUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2.git",
 				RemoteUrl:    "git@bitbucket.org:atlassian/atlaskit-mk-2.git",
 				QueryUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2/src/develop/services/",
-				DirPath:      "repository/atlassian/atlaskit-mk-2/develop",
+				DirPath:      keyDirPath(Key{Hostname: "bitbucket.org", Namespace: "atlassian", Name: "atlaskit-mk-2", RefKind: RefBranch, Ref: "develop"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "bitbucket.org",
+				Forge:        ForgeBitbucket,
 				RawPath:      "/atlassian/atlaskit-mk-2/src/develop/services",
 				Path:         "services",
 				Owner:        "atlassian",
 				Name:         "atlaskit-mk-2",
 				DummyBranch:  "gitd-branch",
 				Branch:       "develop",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://bitbucket.org/atlassian/atlaskit-mk-2/get/develop.zip",
 				FileUrl:      "https://bitbucket.org/atlassian/atlaskit-mk-2/raw/develop/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -982,17 +1062,19 @@ func TestGitRepository_BitbucketParse(t *testing.T) {
 				CloneUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2.git",
 				RemoteUrl:    "git@bitbucket.org:atlassian/atlaskit-mk-2.git",
 				QueryUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2/src/develop/services/website-constellation/",
-				DirPath:      "repository/atlassian/atlaskit-mk-2/develop",
+				DirPath:      keyDirPath(Key{Hostname: "bitbucket.org", Namespace: "atlassian", Name: "atlaskit-mk-2", RefKind: RefBranch, Ref: "develop"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "bitbucket.org",
+				Forge:        ForgeBitbucket,
 				RawPath:      "/atlassian/atlaskit-mk-2/src/develop/services/website-constellation",
 				Path:         "services/website-constellation",
 				Owner:        "atlassian",
 				Name:         "atlaskit-mk-2",
 				DummyBranch:  "gitd-branch",
 				Branch:       "develop",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://bitbucket.org/atlassian/atlaskit-mk-2/get/develop.zip",
 				FileUrl:      "https://bitbucket.org/atlassian/atlaskit-mk-2/raw/develop/[PATH]",
 				DownloadType: DownloadPartialPackage,
@@ -1012,17 +1094,19 @@ func TestGitRepository_BitbucketParse(t *testing.T) {
 				CloneUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2.git",
 				RemoteUrl:    "git@bitbucket.org:atlassian/atlaskit-mk-2.git",
 				QueryUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2/src/develop/services/website-constellation/",
-				DirPath:      "repository/atlassian/atlaskit-mk-2/develop",
+				DirPath:      keyDirPath(Key{Hostname: "bitbucket.org", Namespace: "atlassian", Name: "atlaskit-mk-2", RefKind: RefBranch, Ref: "develop"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "bitbucket.org",
+				Forge:        ForgeBitbucket,
 				RawPath:      "/atlassian/atlaskit-mk-2/blob/develop/services/website-constellation/gatsby-node.js",
 				Path:         "services/website-constellation/gatsby-node.js",
 				Owner:        "atlassian",
 				Name:         "atlaskit-mk-2",
 				DummyBranch:  "gitd-branch",
 				Branch:       "develop",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://bitbucket.org/atlassian/atlaskit-mk-2/get/develop.zip",
 				FileUrl:      "https://bitbucket.org/atlassian/atlaskit-mk-2/raw/develop/[PATH]",
 				DownloadType: DownloadSingleFile,
@@ -1042,17 +1126,19 @@ func TestGitRepository_BitbucketParse(t *testing.T) {
 				CloneUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2.git",
 				RemoteUrl:    "git@bitbucket.org:atlassian/atlaskit-mk-2.git",
 				QueryUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2/src/ckharrl/CONCLOUD-68878-close-manager-propagation/",
-				DirPath:      "repository/atlassian/atlaskit-mk-2/ckharrl/CONCLOUD-68878-close-manager-propagation",
+				DirPath:      keyDirPath(Key{Hostname: "bitbucket.org", Namespace: "atlassian", Name: "atlaskit-mk-2", RefKind: RefBranch, Ref: "ckharrl/CONCLOUD-68878-close-manager-propagation"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "bitbucket.org",
+				Forge:        ForgeBitbucket,
 				RawPath:      "/atlassian/atlaskit-mk-2/src/ckharrl/CONCLOUD-68878-close-manager-propagation",
 				Path:         "",
 				Owner:        "atlassian",
 				Name:         "atlaskit-mk-2",
 				DummyBranch:  "gitd-branch",
 				Branch:       "ckharrl/CONCLOUD-68878-close-manager-propagation",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://bitbucket.org/atlassian/atlaskit-mk-2/get/ckharrl/CONCLOUD-68878-close-manager-propagation.zip",
 				FileUrl:      "https://bitbucket.org/atlassian/atlaskit-mk-2/raw/ckharrl/CONCLOUD-68878-close-manager-propagation/[PATH]",
 				DownloadType: DownloadFullPackage,
@@ -1072,17 +1158,19 @@ func TestGitRepository_BitbucketParse(t *testing.T) {
 				CloneUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2.git",
 				RemoteUrl:    "git@bitbucket.org:atlassian/atlaskit-mk-2.git",
 				QueryUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2/src/master/",
-				DirPath:      "repository/atlassian/atlaskit-mk-2/master",
+				DirPath:      keyDirPath(Key{Hostname: "bitbucket.org", Namespace: "atlassian", Name: "atlaskit-mk-2", RefKind: RefBranch, Ref: "master"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "bitbucket.org",
+				Forge:        ForgeBitbucket,
 				RawPath:      "/atlassian/atlaskit-mk-2/src/master/README.md",
 				Path:         "README.md",
 				Owner:        "atlassian",
 				Name:         "atlaskit-mk-2",
 				DummyBranch:  "gitd-branch",
 				Branch:       "master",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://bitbucket.org/atlassian/atlaskit-mk-2/get/master.zip",
 				FileUrl:      "https://bitbucket.org/atlassian/atlaskit-mk-2/raw/master/[PATH]",
 				DownloadType: DownloadSingleFile,
@@ -1130,17 +1218,19 @@ func TestGitRepository_BitbucketParse(t *testing.T) {
 				CloneUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2.git",
 				RemoteUrl:    "git@bitbucket.org:atlassian/atlaskit-mk-2.git",
 				QueryUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2/src/ckharrl/CONCLOUD-68878-close-manager-propagation/services/website-constellation/",
-				DirPath:      "repository/atlassian/atlaskit-mk-2/ckharrl/CONCLOUD-68878-close-manager-propagation",
+				DirPath:      keyDirPath(Key{Hostname: "bitbucket.org", Namespace: "atlassian", Name: "atlaskit-mk-2", RefKind: RefBranch, Ref: "ckharrl/CONCLOUD-68878-close-manager-propagation"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "bitbucket.org",
+				Forge:        ForgeBitbucket,
 				RawPath:      "/atlassian/atlaskit-mk-2/blob/ckharrl/CONCLOUD-68878-close-manager-propagation/services/website-constellation/gatsby-node.js",
 				Path:         "services/website-constellation/gatsby-node.js",
 				Owner:        "atlassian",
 				Name:         "atlaskit-mk-2",
 				DummyBranch:  "gitd-branch",
 				Branch:       "ckharrl/CONCLOUD-68878-close-manager-propagation",
+				RefKind:      RefBranch,
 				ArchiveUrl:   "https://bitbucket.org/atlassian/atlaskit-mk-2/get/ckharrl/CONCLOUD-68878-close-manager-propagation.zip",
 				FileUrl:      "https://bitbucket.org/atlassian/atlaskit-mk-2/raw/ckharrl/CONCLOUD-68878-close-manager-propagation/[PATH]",
 				DownloadType: DownloadSingleFile,
@@ -1160,11 +1250,12 @@ func TestGitRepository_BitbucketParse(t *testing.T) {
 				CloneUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2.git",
 				RemoteUrl:    "git@bitbucket.org:atlassian/atlaskit-mk-2.git",
 				QueryUrl:     "https://bitbucket.org/atlassian/atlaskit-mk-2",
-				DirPath:      "repository/atlassian/atlaskit-mk-2/gitd-branch",
+				DirPath:      keyDirPath(Key{Hostname: "bitbucket.org", Namespace: "atlassian", Name: "atlaskit-mk-2", Ref: "gitd-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "bitbucket.org",
+				Forge:        ForgeBitbucket,
 				RawPath:      "/atlassian/atlaskit-mk-2",
 				Path:         "",
 				Owner:        "atlassian",
@@ -1235,11 +1326,12 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli",
-				DirPath:      "repository/cli/cli/gitd-branch",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", Ref: "gitd-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli",
 				Path:         "",
 				Owner:        "cli",
@@ -1267,17 +1359,19 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli/src/branch/bad-branch/",
-				DirPath:      "repository/cli/cli/bad-branch",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "bad-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli/src/branch/bad-branch",
 				Path:         "",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "bad-branch",
+				RefKind:      RefBranch,
 				IsTagBranch:  false,
 				ArchiveUrl:   "https://gitea.com/cli/cli/archive/bad-branch.zip",
 				FileUrl:      "https://gitea.com/cli/cli/raw/branch/bad-branch/[PATH]",
@@ -1299,17 +1393,19 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli/src/tag/bad-branch/",
-				DirPath:      "repository/cli/cli/bad-branch",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", RefKind: RefTag, Ref: "bad-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli/src/tag/bad-branch",
 				Path:         "",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "bad-branch",
+				RefKind:      RefTag,
 				IsTagBranch:  true,
 				ArchiveUrl:   "https://gitea.com/cli/cli/archive/bad-branch.zip",
 				FileUrl:      "https://gitea.com/cli/cli/raw/tag/bad-branch/[PATH]",
@@ -1331,17 +1427,19 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli/src/branch/bad-branch/cmd/",
-				DirPath:      "repository/cli/cli/bad-branch",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "bad-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli/src/branch/bad-branch/cmd",
 				Path:         "cmd",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "bad-branch",
+				RefKind:      RefBranch,
 				IsTagBranch:  false,
 				ArchiveUrl:   "https://gitea.com/cli/cli/archive/bad-branch.zip",
 				FileUrl:      "https://gitea.com/cli/cli/raw/branch/bad-branch/[PATH]",
@@ -1363,17 +1461,19 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli/src/branch/bad-branch/cmd/gh/",
-				DirPath:      "repository/cli/cli/bad-branch",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "bad-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli/src/branch/bad-branch/cmd/gh",
 				Path:         "cmd/gh",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "bad-branch",
+				RefKind:      RefBranch,
 				IsTagBranch:  false,
 				ArchiveUrl:   "https://gitea.com/cli/cli/archive/bad-branch.zip",
 				FileUrl:      "https://gitea.com/cli/cli/raw/branch/bad-branch/[PATH]",
@@ -1395,17 +1495,19 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli/src/branch/develop/services/website-constellation/",
-				DirPath:      "repository/cli/cli/develop",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "develop"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli/src/branch/develop/services/website-constellation/gatsby-node.js",
 				Path:         "services/website-constellation/gatsby-node.js",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "develop",
+				RefKind:      RefBranch,
 				IsTagBranch:  false,
 				ArchiveUrl:   "https://gitea.com/cli/cli/archive/develop.zip",
 				FileUrl:      "https://gitea.com/cli/cli/raw/branch/develop/[PATH]",
@@ -1427,17 +1529,19 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli/src/branch/ckharrl/CONCLOUD-68878-close-manager-propagation/",
-				DirPath:      "repository/cli/cli/ckharrl/CONCLOUD-68878-close-manager-propagation",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "ckharrl/CONCLOUD-68878-close-manager-propagation"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli/src/branch/ckharrl/CONCLOUD-68878-close-manager-propagation",
 				Path:         "",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "ckharrl/CONCLOUD-68878-close-manager-propagation",
+				RefKind:      RefBranch,
 				IsTagBranch:  false,
 				ArchiveUrl:   "https://gitea.com/cli/cli/archive/ckharrl/CONCLOUD-68878-close-manager-propagation.zip",
 				FileUrl:      "https://gitea.com/cli/cli/raw/branch/ckharrl/CONCLOUD-68878-close-manager-propagation/[PATH]",
@@ -1459,17 +1563,19 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli/src/branch/trunk/",
-				DirPath:      "repository/cli/cli/trunk",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "trunk"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli/src/branch/trunk/Makefile",
 				Path:         "Makefile",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "trunk",
+				RefKind:      RefBranch,
 				IsTagBranch:  false,
 				ArchiveUrl:   "https://gitea.com/cli/cli/archive/trunk.zip",
 				FileUrl:      "https://gitea.com/cli/cli/raw/branch/trunk/[PATH]",
@@ -1519,17 +1625,19 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli/src/branch/ckharrl/CONCLOUD-68878-close-manager-propagation/services/website-constellation/",
-				DirPath:      "repository/cli/cli/ckharrl/CONCLOUD-68878-close-manager-propagation",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "ckharrl/CONCLOUD-68878-close-manager-propagation"}),
 				IsFile:       true,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli/src/branch/ckharrl/CONCLOUD-68878-close-manager-propagation/services/website-constellation/gatsby-node.js",
 				Path:         "services/website-constellation/gatsby-node.js",
 				Owner:        "cli",
 				Name:         "cli",
 				DummyBranch:  "gitd-branch",
 				Branch:       "ckharrl/CONCLOUD-68878-close-manager-propagation",
+				RefKind:      RefBranch,
 				IsTagBranch:  false,
 				ArchiveUrl:   "https://gitea.com/cli/cli/archive/ckharrl/CONCLOUD-68878-close-manager-propagation.zip",
 				FileUrl:      "https://gitea.com/cli/cli/raw/branch/ckharrl/CONCLOUD-68878-close-manager-propagation/[PATH]",
@@ -1551,11 +1659,12 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 				CloneUrl:     "https://gitea.com/cli/cli.git",
 				RemoteUrl:    "git@gitea.com:cli/cli.git",
 				QueryUrl:     "https://gitea.com/cli/cli",
-				DirPath:      "repository/cli/cli/gitd-branch",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "cli", Name: "cli", Ref: "gitd-branch"}),
 				IsFile:       false,
 				Protocol:     "https",
 				Scheme:       "https",
 				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
 				RawPath:      "/cli/cli",
 				Path:         "",
 				Owner:        "cli",
@@ -1603,3 +1712,30 @@ func TestGitRepository_GiteaParse(t *testing.T) {
 		})
 	}
 }
+
+func TestGitRepository_WithoutCloneUrl(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		want       string
+		wantString string
+	}{
+		{name: "Github Repository", url: "https://github.com/cli/cli.git", want: "https://github.com/cli/cli", wantString: "https://github.com/cli/cli"},
+		{name: "Host With Git", url: "https://code.git.example.com/team/app/tree/main/cmd", want: "https://code.git.example.com/team/app", wantString: "https://code.git.example.com/team/app/tree/main/cmd/"},
+		{name: "Owner With Git", url: "https://github.com/team.github/app", want: "https://github.com/team.github/app", wantString: "https://github.com/team.github/app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGitRepository("", "", tt.url, "")
+			if err := r.Parse("", DirectionNone, ""); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+			if got := r.WithoutCloneUrl(); got != tt.want {
+				t.Errorf("GitRepository.WithoutCloneUrl() = %v, want %v", got, tt.want)
+			}
+			if got := r.String(); got != tt.wantString {
+				t.Errorf("GitRepository.String() = %v, want %v", got, tt.wantString)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// gitea ref kinds after src, raw and media routes
+var giteaRefKinds = map[string]string{
+	"branch": RefBranch,
+	"tag":    RefTag,
+	"commit": RefCommit,
+}
+
+// archive extensions of gitea and forgejo
+var giteaArchiveExtensions = []string{".tar.gz", ".zip", ".bundle"}
+
+// gitea and forgejo provider, forgejo is a gitea fork with the same routes
+type giteaProvider struct {
+	GenericProvider
+	forge     string
+	hostnames []string
+}
+
+func (p giteaProvider) Forge() string {
+	return p.forge
+}
+
+func (p giteaProvider) Match(u *url.URL) bool {
+	return matchHostname(u, p.hostnames...)
+}
+
+func (giteaProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseGiteaRoute(u, filename, true)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/src/[KIND]/[BRANCH]/[PATH]
+func (giteaProvider) BrowseUrl(l *Location) string {
+	if l.Branch != "" {
+		return l.getBaseUrl() + "/src/" + l.getGiteaRefKind() + "/" + escapePath(filepath.Join(l.Branch, l.Path))
+	}
+	return l.getBaseUrl()
+}
+
+// owners and names are case insensitive, gogs too
+func (giteaProvider) foldCase(l *Location) {
+	l.lowerOwnerName()
+}
+
+// /[OWNER]/[NAME]/src|raw|media/branch|tag|commit/[BRANCH]/[PATH]
+func (giteaProvider) routePath(l *Location) string {
+	return l.getGiteaRoutePath(l.getGiteaRefKind())
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/archive/[BRANCH].[EXT]
+// gitea archive url redirect always, commit hashes work too
+func (giteaProvider) ArchiveUrl(l *Location) string {
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/archive/" + l.Branch + ".zip"
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/raw/branch/[BRANCH]/[PATH]
+// https://[HOSTNAME]/[OWNER]/[NAME]/raw/tag/[BRANCH]/[PATH]
+// https://[HOSTNAME]/[OWNER]/[NAME]/raw/commit/[COMMIT]/[PATH]
+// https://[HOSTNAME]/[OWNER]/[NAME]/media/branch/[BRANCH]/[PATH] - lfs files
+// https://gitea.com/XIU2/TrackersListCollection/raw/branch/master/LICENSE
+// https://gitea.com/XIU2/TrackersListCollection/raw/tag/20201211/LICENSE
+func (giteaProvider) FileUrl(l *Location, path string) string {
+	route := "raw"
+	if l.IsLfs {
+		route = "media"
+	}
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/" + route + "/" + l.getGiteaRefKind() + "/" + l.Branch + "/" + path
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/src/branch/[BRANCH]/[PATH]
+// https://[HOSTNAME]/[OWNER]/[NAME]/src/tag/[TAG]/[PATH]
+// https://[HOSTNAME]/[OWNER]/[NAME]/src/commit/[COMMIT]/[PATH]
+func (giteaProvider) QueryUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/src/" + l.getGiteaRefKind() + "/" + escapePath(filepath.Join(l.Branch, path)) + "/"
+}
+
+// gogs provider, gitea old style routes without ref kinds
+type gogsProvider struct {
+	giteaProvider
+}
+
+func (gogsProvider) Forge() string {
+	return ForgeGogs
+}
+
+func (gogsProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "try.gogs.io")
+}
+
+func (gogsProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseGiteaRoute(u, filename, false)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/src/[BRANCH]/[PATH]
+func (gogsProvider) BrowseUrl(l *Location) string {
+	if l.Branch != "" {
+		return l.getBaseUrl() + "/src/" + escapePath(filepath.Join(l.Branch, l.Path))
+	}
+	return l.getBaseUrl()
+}
+
+// /[OWNER]/[NAME]/src|raw/[BRANCH]/[PATH]
+func (gogsProvider) routePath(l *Location) string {
+	return l.getGiteaRoutePath("")
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/raw/[BRANCH]/[PATH]
+func (gogsProvider) FileUrl(l *Location, path string) string {
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/raw/" + l.Branch + "/" + path
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/src/[BRANCH]/[PATH]
+func (gogsProvider) QueryUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/src/" + escapePath(filepath.Join(l.Branch, path)) + "/"
+}
+
+// parse gitea, forgejo and gogs routes, gitea.com, codeberg.org, try.gogs.io and self-hosted instances
+// gogs routes are gitea old style routes
+/*
+https://<hostname>/<owner>/<repo>
+https://<hostname>/<owner>/<repo>/src/branch/<branch>/<path>
+https://<hostname>/<owner>/<repo>/src/tag/<tag>/<path>
+https://<hostname>/<owner>/<repo>/src/commit/<commit>/<path>
+https://<hostname>/<owner>/<repo>/src/<branch>/<path> -> old style, ref kind missing
+https://<hostname>/<owner>/<repo>/raw/branch/<branch>/<path> -> single file
+https://<hostname>/<owner>/<repo>/media/branch/<branch>/<path> -> single file, lfs content
+https://<hostname>/<owner>/<repo>/archive/<branch>.zip
+https://try.gogs.io/<owner>/<repo>/src/<branch>/<path> -> gogs, ref kind never exists
+*/
+func (l *Location) parseGiteaRoute(u *url.URL, filename string, refKinds bool) error {
+	if err := requireOwnerName(strings.Split(strings.TrimPrefix(u.Path, "/"), "/")); err != nil {
+		return err
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	l.Owner = segments[0]
+	l.Name = trimGitSuffix(segments[1])
+	if l.Name == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+	segments[1] = l.Name
+	l.RawPath = strings.TrimSuffix(filepath.Join("/"+strings.Join(segments, "/"), filename), "/")
+
+	route, rest := "", segments[2:]
+	if len(rest) > 0 {
+		route, rest = rest[0], rest[1:]
+	}
+
+	l.traceRoute(route)
+	switch route {
+	case "":
+	case "src", "raw", "media":
+		if len(rest) == 0 {
+			return newParseError(ErrInvalidRef, "")
+		}
+		if kind, ok := giteaRefKinds[rest[0]]; ok && refKinds {
+			l.RefKind, rest = kind, rest[1:]
+		}
+		if len(rest) == 0 {
+			return newParseError(ErrInvalidRef, "")
+		}
+
+		// user set branch name first, commits have no slashes
+		joined := strings.Join(rest, "/")
+		if l.RefKind == RefCommit || l.Branch == "" || (joined != l.Branch && !strings.HasPrefix(joined, l.Branch+"/")) {
+			l.Branch = rest[0]
+		}
+		l.Path = strings.TrimPrefix(strings.TrimPrefix(joined, l.Branch), "/")
+		l.IsTagBranch = l.RefKind == RefTag
+		l.IsLfs = route == "media"
+	case "archive":
+		// <branch>.zip, branch names with slashes
+		ref := strings.Join(rest, "/")
+		for _, extension := range giteaArchiveExtensions {
+			if branch, ok := strings.CutSuffix(ref, extension); ok && branch != "" {
+				l.Branch = branch
+				break
+			}
+		}
+		if l.Branch == "" {
+			return newParseError(ErrInvalidRef, ref)
+		}
+	default:
+		return newParseError(ErrUnknownRoute, route)
+	}
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+
+	// raw and media routes only serve files, src urls end with slash for folders
+	l.IsFile = filename != "" || (l.Path != "" && (route == "raw" || route == "media" || !strings.HasSuffix(u.Path, "/")))
+
+	return nil
+}
+
+// generate gitea url path, route of raw path stays, archive urls become src urls
+func (l *Location) getGiteaRoutePath(refKind string) string {
+	if l.Branch == "" {
+		return "/" + l.Owner + "/" + l.Name
+	}
+
+	route := l.getPositionalRoute()
+	if route != "raw" && route != "media" {
+		route = "src"
+	}
+	if refKind != "" {
+		route += "/" + refKind
+	}
+
+	return strings.TrimSuffix("/"+l.Owner+"/"+l.Name+"/"+route+"/"+l.Branch+"/"+l.Path, "/")
+}
+
+// generate gitea ref kind segment, branch if kind is unknown
+func (l *Location) getGiteaRefKind() string {
+	switch {
+	case l.RefKind == RefTag || l.IsTagBranch:
+		return "tag"
+	case l.RefKind == RefCommit:
+		return "commit"
+	}
+
+	return "branch"
+}
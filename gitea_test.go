@@ -0,0 +1,789 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_ForgejoParse(t *testing.T) {
+	restoreRegistry(t)
+	RegisterForge("git.example.net", ForgeForgejo)
+
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Codeberg Repository",
+			url:    "https://codeberg.org/forgejo/forgejo",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo",
+				Path:         "",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/raw/branch//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Repository Git Url",
+			url:    "https://codeberg.org/forgejo/forgejo.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo.git",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo",
+				Path:         "",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/raw/branch//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Branch Folder",
+			url:    "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/routers/web/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/routers/web",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/routers/web/",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/routers/web/",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", RefKind: RefBranch, Ref: "forgejo"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo/src/branch/forgejo/routers/web",
+				Path:         "routers/web",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "forgejo",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/forgejo.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/raw/branch/forgejo/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Tag Single File",
+			url:    "https://codeberg.org/forgejo/forgejo/src/tag/v1.21.0/go.mod",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo/src/tag/v1.21.0/go.mod",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo/src/tag/v1.21.0/go.mod",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo/src/tag/v1.21.0/",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", RefKind: RefTag, Ref: "v1.21.0"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo/src/tag/v1.21.0/go.mod",
+				Path:         "go.mod",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v1.21.0",
+				RefKind:      RefTag,
+				IsTagBranch:  true,
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/v1.21.0.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/raw/tag/v1.21.0/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Commit Folder",
+			url:    "https://codeberg.org/forgejo/forgejo/src/commit/0123456789abcdef0123456789abcdef01234567/routers/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo/src/commit/0123456789abcdef0123456789abcdef01234567/routers",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo/src/commit/0123456789abcdef0123456789abcdef01234567/routers/",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo/src/commit/0123456789abcdef0123456789abcdef01234567/routers/",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", RefKind: RefCommit, Ref: "0123456789abcdef0123456789abcdef01234567"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo/src/commit/0123456789abcdef0123456789abcdef01234567/routers",
+				Path:         "routers",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "0123456789abcdef0123456789abcdef01234567",
+				RefKind:      RefCommit,
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/0123456789abcdef0123456789abcdef01234567.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/raw/commit/0123456789abcdef0123456789abcdef01234567/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Slashes Branch Single File",
+			url:    "https://codeberg.org/forgejo/forgejo/src/branch/v7.0/forgejo/Makefile",
+			branch: "v7.0/forgejo",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo/src/branch/v7.0/forgejo/Makefile",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo/src/branch/v7.0/forgejo/Makefile",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo/src/branch/v7.0/forgejo/",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", RefKind: RefBranch, Ref: "v7.0/forgejo"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo/src/branch/v7.0/forgejo/Makefile",
+				Path:         "Makefile",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v7.0/forgejo",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/v7.0/forgejo.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/raw/branch/v7.0/forgejo/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Raw Single File",
+			url:    "https://codeberg.org/forgejo/forgejo/raw/branch/forgejo/README.md",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/README.md",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo/raw/branch/forgejo/README.md",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", RefKind: RefBranch, Ref: "forgejo"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo/raw/branch/forgejo/README.md",
+				Path:         "README.md",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "forgejo",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/forgejo.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/raw/branch/forgejo/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Media Lfs Single File",
+			url:    "https://codeberg.org/forgejo/forgejo/media/branch/forgejo/assets/logo.png",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/assets/logo.png",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo/media/branch/forgejo/assets/logo.png",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/assets/",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", RefKind: RefBranch, Ref: "forgejo"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo/media/branch/forgejo/assets/logo.png",
+				Path:         "assets/logo.png",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "forgejo",
+				RefKind:      RefBranch,
+				IsLfs:        true,
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/forgejo.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/media/branch/forgejo/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Archive Url",
+			url:    "https://codeberg.org/forgejo/forgejo/archive/forgejo.zip",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo/src/branch/forgejo",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo/archive/forgejo.zip",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", RefKind: RefBranch, Ref: "forgejo"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo/archive/forgejo.zip",
+				Path:         "",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "forgejo",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/forgejo.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/raw/branch/forgejo/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Old Style Branch Folder",
+			url:    "https://codeberg.org/forgejo/forgejo/src/forgejo/routers/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/routers",
+				RawUrl:       "https://codeberg.org/forgejo/forgejo/src/forgejo/routers/",
+				CloneUrl:     "https://codeberg.org/forgejo/forgejo.git",
+				RemoteUrl:    "git@codeberg.org:forgejo/forgejo.git",
+				QueryUrl:     "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/routers/",
+				DirPath:      keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", RefKind: RefBranch, Ref: "forgejo"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "codeberg.org",
+				Forge:        ForgeForgejo,
+				RawPath:      "/forgejo/forgejo/src/forgejo/routers",
+				Path:         "routers",
+				Owner:        "forgejo",
+				Name:         "forgejo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "forgejo",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://codeberg.org/forgejo/forgejo/archive/forgejo.zip",
+				FileUrl:      "https://codeberg.org/forgejo/forgejo/raw/branch/forgejo/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Registered Forgejo Host",
+			url:    "https://git.example.net/team/app/src/branch/main/cmd/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.example.net/team/app/src/branch/main/cmd",
+				RawUrl:       "https://git.example.net/team/app/src/branch/main/cmd/",
+				CloneUrl:     "https://git.example.net/team/app.git",
+				RemoteUrl:    "git@git.example.net:team/app.git",
+				QueryUrl:     "https://git.example.net/team/app/src/branch/main/cmd/",
+				DirPath:      keyDirPath(Key{Hostname: "git.example.net", Namespace: "team", Name: "app", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.example.net",
+				Forge:        ForgeForgejo,
+				RawPath:      "/team/app/src/branch/main/cmd",
+				Path:         "cmd",
+				Owner:        "team",
+				Name:         "app",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.example.net/team/app/archive/main.zip",
+				FileUrl:      "https://git.example.net/team/app/raw/branch/main/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitea Commit Single File",
+			url:    "https://gitea.com/gitea/tea/src/commit/0123456789abcdef0123456789abcdef01234567/main.go",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitea.com/gitea/tea/src/commit/0123456789abcdef0123456789abcdef01234567/main.go",
+				RawUrl:       "https://gitea.com/gitea/tea/src/commit/0123456789abcdef0123456789abcdef01234567/main.go",
+				CloneUrl:     "https://gitea.com/gitea/tea.git",
+				RemoteUrl:    "git@gitea.com:gitea/tea.git",
+				QueryUrl:     "https://gitea.com/gitea/tea/src/commit/0123456789abcdef0123456789abcdef01234567/",
+				DirPath:      keyDirPath(Key{Hostname: "gitea.com", Namespace: "gitea", Name: "tea", RefKind: RefCommit, Ref: "0123456789abcdef0123456789abcdef01234567"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitea.com",
+				Forge:        ForgeGitea,
+				RawPath:      "/gitea/tea/src/commit/0123456789abcdef0123456789abcdef01234567/main.go",
+				Path:         "main.go",
+				Owner:        "gitea",
+				Name:         "tea",
+				DummyBranch:  "gitd-branch",
+				Branch:       "0123456789abcdef0123456789abcdef01234567",
+				RefKind:      RefCommit,
+				ArchiveUrl:   "https://gitea.com/gitea/tea/archive/0123456789abcdef0123456789abcdef01234567.zip",
+				FileUrl:      "https://gitea.com/gitea/tea/raw/commit/0123456789abcdef0123456789abcdef01234567/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Codeberg Unknown Route",
+			url:    "https://codeberg.org/forgejo/forgejo/issues/1",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://codeberg.org/forgejo/forgejo/issues/1",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "codeberg.org",
+				Forge:       ForgeForgejo,
+				RawPath:     "/forgejo/forgejo/issues/1",
+				Path:        "",
+				Owner:       "forgejo",
+				Name:        "forgejo",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Parse Codeberg Src Without Branch",
+			url:    "https://codeberg.org/forgejo/forgejo/src/branch/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://codeberg.org/forgejo/forgejo/src/branch/",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "codeberg.org",
+				Forge:       ForgeForgejo,
+				RawPath:     "/forgejo/forgejo/src/branch",
+				Path:        "",
+				Owner:       "forgejo",
+				Name:        "forgejo",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+				RefKind:     RefBranch,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+func TestGitRepository_GogsParse(t *testing.T) {
+	restoreRegistry(t)
+	RegisterForge("gogs.example.com", ForgeGogs)
+
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Gogs Repository",
+			url:    "https://try.gogs.io/gogs/gogs",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://try.gogs.io/gogs/gogs",
+				RawUrl:       "https://try.gogs.io/gogs/gogs",
+				CloneUrl:     "https://try.gogs.io/gogs/gogs.git",
+				RemoteUrl:    "git@try.gogs.io:gogs/gogs.git",
+				QueryUrl:     "https://try.gogs.io/gogs/gogs",
+				DirPath:      keyDirPath(Key{Hostname: "try.gogs.io", Namespace: "gogs", Name: "gogs", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "try.gogs.io",
+				Forge:        ForgeGogs,
+				RawPath:      "/gogs/gogs",
+				Path:         "",
+				Owner:        "gogs",
+				Name:         "gogs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://try.gogs.io/gogs/gogs/archive/.zip",
+				FileUrl:      "https://try.gogs.io/gogs/gogs/raw//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gogs Folder",
+			url:    "https://try.gogs.io/gogs/gogs/src/main/internal/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://try.gogs.io/gogs/gogs/src/main/internal",
+				RawUrl:       "https://try.gogs.io/gogs/gogs/src/main/internal/",
+				CloneUrl:     "https://try.gogs.io/gogs/gogs.git",
+				RemoteUrl:    "git@try.gogs.io:gogs/gogs.git",
+				QueryUrl:     "https://try.gogs.io/gogs/gogs/src/main/internal/",
+				DirPath:      keyDirPath(Key{Hostname: "try.gogs.io", Namespace: "gogs", Name: "gogs", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "try.gogs.io",
+				Forge:        ForgeGogs,
+				RawPath:      "/gogs/gogs/src/main/internal",
+				Path:         "internal",
+				Owner:        "gogs",
+				Name:         "gogs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://try.gogs.io/gogs/gogs/archive/main.zip",
+				FileUrl:      "https://try.gogs.io/gogs/gogs/raw/main/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gogs Single File",
+			url:    "https://try.gogs.io/gogs/gogs/src/main/README.md",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://try.gogs.io/gogs/gogs/src/main/README.md",
+				RawUrl:       "https://try.gogs.io/gogs/gogs/src/main/README.md",
+				CloneUrl:     "https://try.gogs.io/gogs/gogs.git",
+				RemoteUrl:    "git@try.gogs.io:gogs/gogs.git",
+				QueryUrl:     "https://try.gogs.io/gogs/gogs/src/main/",
+				DirPath:      keyDirPath(Key{Hostname: "try.gogs.io", Namespace: "gogs", Name: "gogs", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "try.gogs.io",
+				Forge:        ForgeGogs,
+				RawPath:      "/gogs/gogs/src/main/README.md",
+				Path:         "README.md",
+				Owner:        "gogs",
+				Name:         "gogs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://try.gogs.io/gogs/gogs/archive/main.zip",
+				FileUrl:      "https://try.gogs.io/gogs/gogs/raw/main/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gogs Raw Single File",
+			url:    "https://try.gogs.io/gogs/gogs/raw/v0.13.0/README.md",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://try.gogs.io/gogs/gogs/src/v0.13.0/README.md",
+				RawUrl:       "https://try.gogs.io/gogs/gogs/raw/v0.13.0/README.md",
+				CloneUrl:     "https://try.gogs.io/gogs/gogs.git",
+				RemoteUrl:    "git@try.gogs.io:gogs/gogs.git",
+				QueryUrl:     "https://try.gogs.io/gogs/gogs/src/v0.13.0/",
+				DirPath:      keyDirPath(Key{Hostname: "try.gogs.io", Namespace: "gogs", Name: "gogs", RefKind: RefBranch, Ref: "v0.13.0"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "try.gogs.io",
+				Forge:        ForgeGogs,
+				RawPath:      "/gogs/gogs/raw/v0.13.0/README.md",
+				Path:         "README.md",
+				Owner:        "gogs",
+				Name:         "gogs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v0.13.0",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://try.gogs.io/gogs/gogs/archive/v0.13.0.zip",
+				FileUrl:      "https://try.gogs.io/gogs/gogs/raw/v0.13.0/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gogs Slashes Branch Single File",
+			url:    "https://try.gogs.io/gogs/gogs/src/release/0.13/Makefile",
+			branch: "release/0.13",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://try.gogs.io/gogs/gogs/src/release/0.13/Makefile",
+				RawUrl:       "https://try.gogs.io/gogs/gogs/src/release/0.13/Makefile",
+				CloneUrl:     "https://try.gogs.io/gogs/gogs.git",
+				RemoteUrl:    "git@try.gogs.io:gogs/gogs.git",
+				QueryUrl:     "https://try.gogs.io/gogs/gogs/src/release/0.13/",
+				DirPath:      keyDirPath(Key{Hostname: "try.gogs.io", Namespace: "gogs", Name: "gogs", RefKind: RefBranch, Ref: "release/0.13"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "try.gogs.io",
+				Forge:        ForgeGogs,
+				RawPath:      "/gogs/gogs/src/release/0.13/Makefile",
+				Path:         "Makefile",
+				Owner:        "gogs",
+				Name:         "gogs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "release/0.13",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://try.gogs.io/gogs/gogs/archive/release/0.13.zip",
+				FileUrl:      "https://try.gogs.io/gogs/gogs/raw/release/0.13/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gogs Archive Url",
+			url:    "https://try.gogs.io/gogs/gogs/archive/v0.13.0.zip",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://try.gogs.io/gogs/gogs/src/v0.13.0",
+				RawUrl:       "https://try.gogs.io/gogs/gogs/archive/v0.13.0.zip",
+				CloneUrl:     "https://try.gogs.io/gogs/gogs.git",
+				RemoteUrl:    "git@try.gogs.io:gogs/gogs.git",
+				QueryUrl:     "https://try.gogs.io/gogs/gogs/src/v0.13.0/",
+				DirPath:      keyDirPath(Key{Hostname: "try.gogs.io", Namespace: "gogs", Name: "gogs", RefKind: RefBranch, Ref: "v0.13.0"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "try.gogs.io",
+				Forge:        ForgeGogs,
+				RawPath:      "/gogs/gogs/archive/v0.13.0.zip",
+				Path:         "",
+				Owner:        "gogs",
+				Name:         "gogs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v0.13.0",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://try.gogs.io/gogs/gogs/archive/v0.13.0.zip",
+				FileUrl:      "https://try.gogs.io/gogs/gogs/raw/v0.13.0/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gogs Registered Host",
+			url:    "https://gogs.example.com/team/app/src/develop/cmd/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gogs.example.com/team/app/src/develop/cmd",
+				RawUrl:       "https://gogs.example.com/team/app/src/develop/cmd/",
+				CloneUrl:     "https://gogs.example.com/team/app.git",
+				RemoteUrl:    "git@gogs.example.com:team/app.git",
+				QueryUrl:     "https://gogs.example.com/team/app/src/develop/cmd/",
+				DirPath:      keyDirPath(Key{Hostname: "gogs.example.com", Namespace: "team", Name: "app", RefKind: RefBranch, Ref: "develop"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gogs.example.com",
+				Forge:        ForgeGogs,
+				RawPath:      "/team/app/src/develop/cmd",
+				Path:         "cmd",
+				Owner:        "team",
+				Name:         "app",
+				DummyBranch:  "gitd-branch",
+				Branch:       "develop",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://gogs.example.com/team/app/archive/develop.zip",
+				FileUrl:      "https://gogs.example.com/team/app/raw/develop/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+func TestParseURL_GiteaEscapedQueryUrl(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		wantUrl      string
+		wantQueryUrl string
+	}{
+		{
+			name:         "Gitea Escaped Folder",
+			url:          "https://gitea.com/gitea/tea/src/branch/feat%23x/a%20b/",
+			wantUrl:      "https://gitea.com/gitea/tea/src/branch/feat%23x/a%20b",
+			wantQueryUrl: "https://gitea.com/gitea/tea/src/branch/feat%23x/a%20b/",
+		},
+		{
+			name:         "Gogs Escaped Folder",
+			url:          "https://try.gogs.io/gogs/gogs/src/feat%23x/a%20b/",
+			wantUrl:      "https://try.gogs.io/gogs/gogs/src/feat%23x/a%20b",
+			wantQueryUrl: "https://try.gogs.io/gogs/gogs/src/feat%23x/a%20b/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := ParseURL(tt.url, BranchHint("feat#x"))
+			if err != nil {
+				t.Fatalf("ParseURL() error = %v", err)
+			}
+			if l.Url != tt.wantUrl || l.QueryUrl != tt.wantQueryUrl {
+				t.Errorf("ParseURL() Url = %v, QueryUrl = %v, want %v, %v", l.Url, l.QueryUrl, tt.wantUrl, tt.wantQueryUrl)
+			}
+		})
+	}
+}
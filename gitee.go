@@ -0,0 +1,203 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// archive extensions of gitee and gitcode
+var giteeArchiveExtensions = []string{".tar.gz", ".zip"}
+
+// gitee provider
+type giteeProvider struct {
+	GenericProvider
+}
+
+func (giteeProvider) Forge() string {
+	return ForgeGitee
+}
+
+func (giteeProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "gitee.com")
+}
+
+func (giteeProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseGiteeRoute(u, filename)
+}
+
+// owners and names are case insensitive, raw path is the repository path
+func (giteeProvider) foldCase(l *Location) {
+	l.lowerOwnerName()
+	l.RawPath = strings.ToLower(l.RawPath)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/tree|blob/[BRANCH]/[PATH]
+func (giteeProvider) BrowseUrl(l *Location) string {
+	return l.getGiteeTreeUrl(l.Path)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/repository/archive/[BRANCH].[EXT]
+// tags and commit hashes work too
+func (giteeProvider) ArchiveUrl(l *Location) string {
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/repository/archive/" + l.getGiteeRef() + ".zip"
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/raw/[BRANCH]/[PATH]
+// https://gitee.com/micovery/sock-rpc/raw/dev/package.json
+// https://gitee.com/micovery/sock-rpc/raw/refs/tags/v1.0.0/package.json
+func (giteeProvider) FileUrl(l *Location, path string) string {
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/raw/" + l.getGiteeRef() + "/" + path
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/tree/[BRANCH]/[PATH]
+func (giteeProvider) QueryUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/tree/" + filepath.Join(l.getGiteeRef(), escapePath(path)) + "/"
+}
+
+// gitcode provider, gitee routes with gitlab downloads
+type gitCodeProvider struct {
+	giteeProvider
+}
+
+func (gitCodeProvider) Forge() string {
+	return ForgeGitCode
+}
+
+func (gitCodeProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "gitcode.com")
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/-/archive/[BRANCH]/[NAME]-[BRANCH].[EXT]
+// gitcode archive urls are gitlab archive urls
+func (gitCodeProvider) ArchiveUrl(l *Location) string {
+	ref := l.getGiteeRef()
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/-/archive/" + ref + "/" + l.Name + "-" + strings.ReplaceAll(ref, "/", "-") + ".zip"
+}
+
+// https://raw.gitcode.com/[OWNER]/[NAME]/raw/[BRANCH]/[PATH]
+func (gitCodeProvider) FileUrl(l *Location, path string) string {
+	return "https://raw." + l.Hostname + "/" + l.Owner + "/" + l.Name + "/raw/" + l.getGiteeRef() + "/" + path
+}
+
+// parse gitee and gitcode routes, tree urls do not tell branch or tag
+/*
+https://gitee.com/<owner>/<repo>
+https://gitee.com/<owner>/<repo>/tree/<branch>/<path> -> folder
+https://gitee.com/<owner>/<repo>/blob/<branch>/<path> -> single file
+https://gitee.com/<owner>/<repo>/raw/<branch>/<path> -> single file
+https://gitee.com/<owner>/<repo>/tree/<commit>/<path> -> commit hash
+https://gitee.com/<owner>/<repo>/tree/refs/tags/<tag>/<path> -> tag, same with refs/tags/<tag> branch name
+https://gitee.com/<owner>/<repo>/releases/tag/<tag> -> tag
+https://gitee.com/<owner>/<repo>/commit/<commit> -> commit
+https://gitee.com/<owner>/<repo>/repository/archive/<branch>.zip
+https://gitcode.com/<owner>/<repo>/tree/<branch>/<path> -> gitcode, same routes
+*/
+func (l *Location) parseGiteeRoute(u *url.URL, filename string) error {
+	if err := requireOwnerName(strings.Split(strings.TrimPrefix(u.Path, "/"), "/")); err != nil {
+		return err
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	l.Owner = segments[0]
+	l.Name = trimGitSuffix(segments[1])
+	if l.Name == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+	l.RawPath = "/" + l.Owner + "/" + l.Name
+
+	route, rest := "", segments[2:]
+	if len(rest) > 0 {
+		route, rest = rest[0], rest[1:]
+	}
+
+	// user set refs/tags/<tag> branch name
+	if l.Branch != "" {
+		l.Branch, l.RefKind = splitRef(l.Branch)
+	}
+
+	l.traceRoute(route)
+	switch route {
+	case "":
+	case "tree", "blob", "raw":
+		if len(rest) == 0 {
+			return newParseError(ErrInvalidRef, "")
+		}
+
+		// user set branch name first, full ref names later, first segment last
+		joined := strings.Join(rest, "/")
+		ref, kind := rest[0], ""
+		switch {
+		case l.Branch != "" && (joined == l.Branch || strings.HasPrefix(joined, l.Branch+"/")):
+			ref, kind = l.Branch, l.RefKind
+		case rest[0] == "refs" && len(rest) >= 3:
+			ref = strings.Join(rest[:3], "/")
+		}
+		l.Path = strings.TrimPrefix(strings.TrimPrefix(joined, ref), "/")
+
+		l.Branch, l.RefKind = splitRef(ref)
+		if kind != "" {
+			l.RefKind = kind
+		}
+	case "releases":
+		// releases/tag/<tag>
+		if len(rest) != 2 || rest[0] != "tag" {
+			return newParseError(ErrInvalidRef, strings.Join(rest, "/"))
+		}
+		l.Branch, l.RefKind = rest[1], RefTag
+	case "commit":
+		if len(rest) != 1 || !isCommitHash(rest[0]) {
+			return newParseError(ErrInvalidRef, strings.Join(rest, "/"))
+		}
+		l.Branch, l.RefKind = rest[0], RefCommit
+	case "repository":
+		// repository/archive/<branch>.zip
+		if len(rest) < 2 || rest[0] != "archive" {
+			return newParseError(ErrUnknownRoute, strings.Join(rest, "/"))
+		}
+		ref := strings.Join(rest[1:], "/")
+		for _, extension := range giteeArchiveExtensions {
+			if branch, ok := strings.CutSuffix(ref, extension); ok && branch != "" {
+				l.Branch, l.RefKind = splitRef(branch)
+				break
+			}
+		}
+		if l.Branch == "" {
+			return newParseError(ErrInvalidRef, ref)
+		}
+	default:
+		return newParseError(ErrUnknownRoute, route)
+	}
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+
+	// blob and raw routes only serve files, tree route only serves folders
+	l.IsFile = filename != "" || (l.Path != "" && (route == "blob" || route == "raw"))
+
+	return nil
+}
+
+// generate gitee web url
+// https://gitee.com/[OWNER]/[NAME]/tree|blob/[BRANCH]/[PATH]
+// https://gitee.com/[OWNER]/[NAME]/tree|blob/refs/tags/[TAG]/[PATH]
+func (l *Location) getGiteeTreeUrl(path string) string {
+	if l.Branch == "" && path == "" {
+		return l.getBaseUrl()
+	}
+
+	route := "tree"
+	if l.IsFile {
+		route = "blob"
+	}
+
+	return strings.TrimSuffix(l.getBaseUrl()+"/"+route+"/"+filepath.Join(l.getGiteeRef(), escapePath(path)), "/")
+}
+
+// escaped ref of gitee tree, raw and archive urls
+// tree urls do not tell tags, full ref names do
+func (l *Location) getGiteeRef() string {
+	if l.RefKind == RefTag {
+		return "refs/tags/" + escapePath(l.Branch)
+	}
+
+	return escapePath(l.Branch)
+}
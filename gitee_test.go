@@ -0,0 +1,615 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_GiteeParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Gitee Repository",
+			url:    "https://gitee.com/mindspore/mindspore",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore",
+				RawUrl:       "https://gitee.com/mindspore/mindspore",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Repository Git Url",
+			url:    "https://gitee.com/mindspore/mindspore.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore",
+				RawUrl:       "https://gitee.com/mindspore/mindspore.git",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Ssh Remote Url",
+			url:    "git@gitee.com:mindspore/mindspore.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore",
+				RawUrl:       "git@gitee.com:mindspore/mindspore.git",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Folder",
+			url:    "https://gitee.com/mindspore/mindspore/tree/master/mindspore/python/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore/tree/master/mindspore/python",
+				RawUrl:       "https://gitee.com/mindspore/mindspore/tree/master/mindspore/python/",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore/tree/master/mindspore/python/",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "mindspore/python",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/master.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw/master/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Single File",
+			url:    "https://gitee.com/mindspore/mindspore/blob/master/README.md",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore/blob/master/README.md",
+				RawUrl:       "https://gitee.com/mindspore/mindspore/blob/master/README.md",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore/tree/master/",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "README.md",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/master.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw/master/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Raw Single File",
+			url:    "https://gitee.com/mindspore/mindspore/raw/v2.3.0/README.md",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore/blob/v2.3.0/README.md",
+				RawUrl:       "https://gitee.com/mindspore/mindspore/raw/v2.3.0/README.md",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore/tree/v2.3.0/",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", RefKind: RefBranch, Ref: "v2.3.0"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "README.md",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v2.3.0",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/v2.3.0.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw/v2.3.0/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Tag Folder With Full Ref Branch",
+			url:    "https://gitee.com/mindspore/mindspore/tree/v2.3.0/docs",
+			branch: "refs/tags/v2.3.0",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore/tree/refs/tags/v2.3.0/docs",
+				RawUrl:       "https://gitee.com/mindspore/mindspore/tree/v2.3.0/docs",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore/tree/refs/tags/v2.3.0/docs/",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", RefKind: RefTag, Ref: "v2.3.0"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "docs",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v2.3.0",
+				RefKind:      RefTag,
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/refs/tags/v2.3.0.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw/refs/tags/v2.3.0/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Slashes Branch Single File",
+			url:    "https://gitee.com/mindspore/mindspore/blob/release/r2.3/setup.py",
+			branch: "release/r2.3",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore/blob/release/r2.3/setup.py",
+				RawUrl:       "https://gitee.com/mindspore/mindspore/blob/release/r2.3/setup.py",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore/tree/release/r2.3/",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", RefKind: RefBranch, Ref: "release/r2.3"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "setup.py",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "release/r2.3",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/release/r2.3.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw/release/r2.3/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Commit Folder",
+			url:    "https://gitee.com/mindspore/mindspore/tree/0123456789abcdef0123456789abcdef01234567/docs",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore/tree/0123456789abcdef0123456789abcdef01234567/docs",
+				RawUrl:       "https://gitee.com/mindspore/mindspore/tree/0123456789abcdef0123456789abcdef01234567/docs",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore/tree/0123456789abcdef0123456789abcdef01234567/docs/",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", RefKind: RefCommit, Ref: "0123456789abcdef0123456789abcdef01234567"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "docs",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "0123456789abcdef0123456789abcdef01234567",
+				RefKind:      RefCommit,
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/0123456789abcdef0123456789abcdef01234567.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw/0123456789abcdef0123456789abcdef01234567/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Release Tag",
+			url:    "https://gitee.com/mindspore/mindspore/releases/tag/v2.3.0",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore/tree/refs/tags/v2.3.0",
+				RawUrl:       "https://gitee.com/mindspore/mindspore/releases/tag/v2.3.0",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore/tree/refs/tags/v2.3.0/",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", RefKind: RefTag, Ref: "v2.3.0"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v2.3.0",
+				RefKind:      RefTag,
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/refs/tags/v2.3.0.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw/refs/tags/v2.3.0/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Commit",
+			url:    "https://gitee.com/mindspore/mindspore/commit/0123456789abcdef0123456789abcdef01234567",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore/tree/0123456789abcdef0123456789abcdef01234567",
+				RawUrl:       "https://gitee.com/mindspore/mindspore/commit/0123456789abcdef0123456789abcdef01234567",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore/tree/0123456789abcdef0123456789abcdef01234567/",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", RefKind: RefCommit, Ref: "0123456789abcdef0123456789abcdef01234567"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "0123456789abcdef0123456789abcdef01234567",
+				RefKind:      RefCommit,
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/0123456789abcdef0123456789abcdef01234567.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw/0123456789abcdef0123456789abcdef01234567/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Archive Url",
+			url:    "https://gitee.com/mindspore/mindspore/repository/archive/v2.3.0.zip",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitee.com/mindspore/mindspore/tree/v2.3.0",
+				RawUrl:       "https://gitee.com/mindspore/mindspore/repository/archive/v2.3.0.zip",
+				CloneUrl:     "https://gitee.com/mindspore/mindspore.git",
+				RemoteUrl:    "git@gitee.com:mindspore/mindspore.git",
+				QueryUrl:     "https://gitee.com/mindspore/mindspore/tree/v2.3.0/",
+				DirPath:      keyDirPath(Key{Hostname: "gitee.com", Namespace: "mindspore", Name: "mindspore", RefKind: RefBranch, Ref: "v2.3.0"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitee.com",
+				Forge:        ForgeGitee,
+				RawPath:      "/mindspore/mindspore",
+				Path:         "",
+				Owner:        "mindspore",
+				Name:         "mindspore",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v2.3.0",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://gitee.com/mindspore/mindspore/repository/archive/v2.3.0.zip",
+				FileUrl:      "https://gitee.com/mindspore/mindspore/raw/v2.3.0/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitee Unknown Route",
+			url:    "https://gitee.com/mindspore/mindspore/issues",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://gitee.com/mindspore/mindspore/issues",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "gitee.com",
+				Forge:       ForgeGitee,
+				RawPath:     "/mindspore/mindspore",
+				Path:        "",
+				Owner:       "mindspore",
+				Name:        "mindspore",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Parse GitCode Repository",
+			url:    "https://gitcode.com/openharmony/docs",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitcode.com/openharmony/docs",
+				RawUrl:       "https://gitcode.com/openharmony/docs",
+				CloneUrl:     "https://gitcode.com/openharmony/docs.git",
+				RemoteUrl:    "git@gitcode.com:openharmony/docs.git",
+				QueryUrl:     "https://gitcode.com/openharmony/docs",
+				DirPath:      keyDirPath(Key{Hostname: "gitcode.com", Namespace: "openharmony", Name: "docs", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitcode.com",
+				Forge:        ForgeGitCode,
+				RawPath:      "/openharmony/docs",
+				Path:         "",
+				Owner:        "openharmony",
+				Name:         "docs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://gitcode.com/openharmony/docs/-/archive//docs-.zip",
+				FileUrl:      "https://raw.gitcode.com/openharmony/docs/raw//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse GitCode Folder",
+			url:    "https://gitcode.com/openharmony/docs/tree/master/zh-cn/application-dev",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitcode.com/openharmony/docs/tree/master/zh-cn/application-dev",
+				RawUrl:       "https://gitcode.com/openharmony/docs/tree/master/zh-cn/application-dev",
+				CloneUrl:     "https://gitcode.com/openharmony/docs.git",
+				RemoteUrl:    "git@gitcode.com:openharmony/docs.git",
+				QueryUrl:     "https://gitcode.com/openharmony/docs/tree/master/zh-cn/application-dev/",
+				DirPath:      keyDirPath(Key{Hostname: "gitcode.com", Namespace: "openharmony", Name: "docs", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitcode.com",
+				Forge:        ForgeGitCode,
+				RawPath:      "/openharmony/docs",
+				Path:         "zh-cn/application-dev",
+				Owner:        "openharmony",
+				Name:         "docs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://gitcode.com/openharmony/docs/-/archive/master/docs-master.zip",
+				FileUrl:      "https://raw.gitcode.com/openharmony/docs/raw/master/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse GitCode Single File",
+			url:    "https://gitcode.com/openharmony/docs/blob/master/README.md",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitcode.com/openharmony/docs/blob/master/README.md",
+				RawUrl:       "https://gitcode.com/openharmony/docs/blob/master/README.md",
+				CloneUrl:     "https://gitcode.com/openharmony/docs.git",
+				RemoteUrl:    "git@gitcode.com:openharmony/docs.git",
+				QueryUrl:     "https://gitcode.com/openharmony/docs/tree/master/",
+				DirPath:      keyDirPath(Key{Hostname: "gitcode.com", Namespace: "openharmony", Name: "docs", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitcode.com",
+				Forge:        ForgeGitCode,
+				RawPath:      "/openharmony/docs",
+				Path:         "README.md",
+				Owner:        "openharmony",
+				Name:         "docs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://gitcode.com/openharmony/docs/-/archive/master/docs-master.zip",
+				FileUrl:      "https://raw.gitcode.com/openharmony/docs/raw/master/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse GitCode Ssh Remote Url",
+			url:    "git@gitcode.com:openharmony/docs.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gitcode.com/openharmony/docs",
+				RawUrl:       "git@gitcode.com:openharmony/docs.git",
+				CloneUrl:     "https://gitcode.com/openharmony/docs.git",
+				RemoteUrl:    "git@gitcode.com:openharmony/docs.git",
+				QueryUrl:     "https://gitcode.com/openharmony/docs",
+				DirPath:      keyDirPath(Key{Hostname: "gitcode.com", Namespace: "openharmony", Name: "docs", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "gitcode.com",
+				Forge:        ForgeGitCode,
+				RawPath:      "/openharmony/docs",
+				Path:         "",
+				Owner:        "openharmony",
+				Name:         "docs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://gitcode.com/openharmony/docs/-/archive//docs-.zip",
+				FileUrl:      "https://raw.gitcode.com/openharmony/docs/raw//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+func TestGitRepository_GiteeTagRef(t *testing.T) {
+	r := NewGitRepository("", "", "https://gitee.com/mindspore/mindspore/releases/tag/v2.3.0", "")
+	if err := r.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+
+	// tree, query, raw and archive urls tell the tag, url parses back as the same tag
+	again := NewGitRepository("", "", r.Url, "")
+	if err := again.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse(%q) error = %v", r.Url, err)
+	}
+	if again.Branch != r.Branch || again.RefKind != RefTag || again.QueryUrl != r.QueryUrl || again.ArchiveUrl != r.ArchiveUrl || again.FileUrl != r.FileUrl {
+		t.Errorf("GitRepository.Parse(%q) = %#v, want %#v", r.Url, again, r)
+	}
+}
+
+func TestParseURL_GiteeEscapedRef(t *testing.T) {
+	l, err := ParseURL("https://gitee.com/mindspore/mindspore/tree/refs/tags/v1%23rc/a%20b")
+	if err != nil {
+		t.Fatalf("ParseURL() error = %v", err)
+	}
+
+	want := map[string]string{
+		"Url":        "https://gitee.com/mindspore/mindspore/tree/refs/tags/v1%23rc/a%20b",
+		"QueryUrl":   "https://gitee.com/mindspore/mindspore/tree/refs/tags/v1%23rc/a%20b/",
+		"ArchiveUrl": "https://gitee.com/mindspore/mindspore/repository/archive/refs/tags/v1%23rc.zip",
+		"FileUrl":    "https://gitee.com/mindspore/mindspore/raw/refs/tags/v1%23rc/[PATH]",
+	}
+	got := map[string]string{"Url": l.Url, "QueryUrl": l.QueryUrl, "ArchiveUrl": l.ArchiveUrl, "FileUrl": l.FileUrl}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DeepEqual got = %#v, want %#v", got, want)
+	}
+}
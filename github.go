@@ -0,0 +1,51 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+)
+
+// github.com provider, positional routes
+type githubProvider struct {
+	GenericProvider
+}
+
+func (githubProvider) Forge() string {
+	return ForgeGitHub
+}
+
+func (githubProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "github.com")
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/tree|blob/[BRANCH]/[PATH]
+func (p githubProvider) BrowseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + escapePath(p.routePath(l))
+}
+
+// owners and names are case insensitive
+func (githubProvider) foldCase(l *Location) {
+	l.lowerOwnerName()
+}
+
+func (githubProvider) routePath(l *Location) string {
+	return l.getPositionalPath("")
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/archive/refs/heads/[BRANCH].[EXT]
+// github archive url redirect always
+// TODO: Redirect to https://codeload.github.com/[OWNER]/[NAME]/zip/refs/heads/[BRANCH]
+func (githubProvider) ArchiveUrl(l *Location) string {
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/archive/refs/heads/" + l.Branch + ".zip"
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/blob/[BRANCH]/[PATH]
+// https://raw.githubusercontent.com/101arrowz/fflate/master/.npmignore
+func (githubProvider) FileUrl(l *Location, path string) string {
+	return "https://raw.githubusercontent.com/" + l.Owner + "/" + l.Name + "/" + l.Branch + "/" + path
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/tree/[BRANCH]/[PATH]
+func (githubProvider) QueryUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/tree/" + filepath.Join(l.Branch, path) + "/"
+}
@@ -0,0 +1,143 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// gitiles provider, googlesource.com and gerrit instances
+type gitilesProvider struct {
+	GenericProvider
+}
+
+func (gitilesProvider) Forge() string {
+	return ForgeGitiles
+}
+
+// https://<project>.googlesource.com gitiles urls
+func (gitilesProvider) Match(u *url.URL) bool {
+	return strings.HasSuffix(strings.ToLower(u.Hostname()), ".googlesource.com")
+}
+
+func (gitilesProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseGitilesRoute(u, filename)
+}
+
+// https://[HOSTNAME]/[NAME]
+func (gitilesProvider) BaseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + l.RawPath
+}
+
+// https://[HOSTNAME]/[NAME]/+/[REF]/[PATH]
+func (p gitilesProvider) BrowseUrl(l *Location) string {
+	if l.Branch != "" {
+		return l.getBaseUrl() + "/+/" + escapePath(filepath.Join(l.getFullRef(), l.Path))
+	}
+	return p.GenericProvider.BrowseUrl(l)
+}
+
+// https://[HOSTNAME]/[NAME]
+func (gitilesProvider) CloneUrl(l *Location) string {
+	return l.getBaseUrl()
+}
+
+// gitiles serves https only
+func (gitilesProvider) RemoteUrl(l *Location) string {
+	return l.getBaseUrl()
+}
+
+// https://[HOSTNAME]/[NAME]/+archive/[REF].tar.gz
+// https://[HOSTNAME]/[NAME]/+archive/[REF]/[PATH].tar.gz
+func (gitilesProvider) ArchiveUrl(l *Location) string {
+	return l.getGitilesArchiveUrl()
+}
+
+// https://[HOSTNAME]/[NAME]/+/[REF]/[PATH]?format=TEXT
+// file content base64 encoded
+func (gitilesProvider) FileUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/+/" + l.getGitilesRef() + "/" + path + "?format=TEXT"
+}
+
+// https://[HOSTNAME]/[NAME]/+/[REF]/[PATH]/
+func (gitilesProvider) QueryUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/+/" + filepath.Join(l.getFullRef(), path) + "/"
+}
+
+// parse gitiles routes, gitiles repositories have no owner and deep names
+/*
+https://<hostname>/<repo>
+https://<hostname>/<deep>/<repo>/+/refs/heads/<branch>/<path>
+https://<hostname>/<deep>/<repo>/+/refs/tags/<tag>/<path>
+https://<hostname>/<deep>/<repo>/+/<branch>/<path>/ -> short ref, folder
+https://<hostname>/<deep>/<repo>/+/<commit>/<path>
+*/
+func (l *Location) parseGitilesRoute(u *url.URL, filename string) error {
+	repository, rest, found := strings.Cut(u.Path, "/+")
+	if found && rest != "" && !strings.HasPrefix(rest, "/") {
+		// /+log/, /+archive/, /+refs routes
+		route, _, _ := strings.Cut(rest, "/")
+		return newParseError(ErrUnknownRoute, "+"+route)
+	}
+
+	l.Name = trimGitSuffix(strings.Trim(repository, "/"))
+	if l.Name == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+	l.RawPath = "/" + l.Name
+
+	if rest = strings.Trim(rest, "/"); rest != "" {
+		ref := l.findGitilesRef(rest)
+		l.Branch, l.RefKind = splitRef(ref)
+		l.Path = strings.Trim(strings.TrimPrefix(rest, ref), "/")
+	} else if found {
+		if err := l.requireRef(); err != nil {
+			return err
+		}
+	}
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+
+	// gitiles folder urls end with slash
+	l.IsFile = filename != "" || (l.Path != "" && !strings.HasSuffix(u.Path, "/") && filepath.Ext(l.Path) != "")
+
+	return nil
+}
+
+// find ref of gitiles /+/ route
+// user set branch name first, full ref names later, first segment last
+func (l *Location) findGitilesRef(rest string) string {
+	if l.Branch != "" {
+		for _, ref := range []string{"refs/heads/" + l.Branch, "refs/tags/" + l.Branch, l.Branch} {
+			if rest == ref || strings.HasPrefix(rest, ref+"/") {
+				return ref
+			}
+		}
+	}
+
+	segments := strings.SplitN(rest, "/", 4)
+	if len(segments) >= 3 && segments[0] == "refs" {
+		return strings.Join(segments[:3], "/")
+	}
+
+	return segments[0]
+}
+
+// generate gitiles ref, HEAD if branch is empty
+func (l *Location) getGitilesRef() string {
+	if l.Branch == "" {
+		return "HEAD"
+	}
+
+	return l.getFullRef()
+}
+
+// generate gitiles archive url, folders archived alone
+// https://[HOSTNAME]/[NAME]/+archive/[REF]/[PATH].tar.gz
+func (l *Location) getGitilesArchiveUrl() string {
+	archivePath := l.getGitilesRef()
+	if l.Path != "" && !l.IsFile {
+		archivePath += "/" + l.Path
+	}
+
+	return l.getBaseUrl() + "/+archive/" + archivePath + ".tar.gz"
+}
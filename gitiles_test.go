@@ -0,0 +1,355 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_GitilesParse(t *testing.T) {
+	restoreRegistry(t)
+	RegisterForge("gerrit.example.com", ForgeGitiles)
+
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Gitiles Repository",
+			url:    "https://go.googlesource.com/tools",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://go.googlesource.com/tools",
+				RawUrl:       "https://go.googlesource.com/tools",
+				CloneUrl:     "https://go.googlesource.com/tools",
+				RemoteUrl:    "https://go.googlesource.com/tools",
+				QueryUrl:     "https://go.googlesource.com/tools",
+				DirPath:      keyDirPath(Key{Hostname: "go.googlesource.com", Name: "tools", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "go.googlesource.com",
+				Forge:        ForgeGitiles,
+				RawPath:      "/tools",
+				Path:         "",
+				Owner:        "",
+				Name:         "tools",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://go.googlesource.com/tools/+archive/HEAD.tar.gz",
+				FileUrl:      "https://go.googlesource.com/tools/+/HEAD/[PATH]?format=TEXT",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitiles Repository Some Folder",
+			url:    "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc",
+				RawUrl:       "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc",
+				CloneUrl:     "https://go.googlesource.com/tools",
+				RemoteUrl:    "https://go.googlesource.com/tools",
+				QueryUrl:     "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc/",
+				DirPath:      keyDirPath(Key{Hostname: "go.googlesource.com", Name: "tools", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "go.googlesource.com",
+				Forge:        ForgeGitiles,
+				RawPath:      "/tools",
+				Path:         "gopls/doc",
+				Owner:        "",
+				Name:         "tools",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://go.googlesource.com/tools/+archive/refs/heads/master/gopls/doc.tar.gz",
+				FileUrl:      "https://go.googlesource.com/tools/+/refs/heads/master/[PATH]?format=TEXT",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitiles Deep Repository Short Ref Folder",
+			url:    "https://android.googlesource.com/platform/frameworks/base/+/main/core/java/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://android.googlesource.com/platform/frameworks/base/+/refs/heads/main/core/java",
+				RawUrl:       "https://android.googlesource.com/platform/frameworks/base/+/main/core/java/",
+				CloneUrl:     "https://android.googlesource.com/platform/frameworks/base",
+				RemoteUrl:    "https://android.googlesource.com/platform/frameworks/base",
+				QueryUrl:     "https://android.googlesource.com/platform/frameworks/base/+/refs/heads/main/core/java/",
+				DirPath:      keyDirPath(Key{Hostname: "android.googlesource.com", Name: "platform/frameworks/base", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "android.googlesource.com",
+				Forge:        ForgeGitiles,
+				RawPath:      "/platform/frameworks/base",
+				Path:         "core/java",
+				Owner:        "",
+				Name:         "platform/frameworks/base",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://android.googlesource.com/platform/frameworks/base/+archive/refs/heads/main/core/java.tar.gz",
+				FileUrl:      "https://android.googlesource.com/platform/frameworks/base/+/refs/heads/main/[PATH]?format=TEXT",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitiles Tag Single File",
+			url:    "https://go.googlesource.com/tools/+/refs/tags/v0.1.0/go.mod",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://go.googlesource.com/tools/+/refs/tags/v0.1.0/go.mod",
+				RawUrl:       "https://go.googlesource.com/tools/+/refs/tags/v0.1.0/go.mod",
+				CloneUrl:     "https://go.googlesource.com/tools",
+				RemoteUrl:    "https://go.googlesource.com/tools",
+				QueryUrl:     "https://go.googlesource.com/tools/+/refs/tags/v0.1.0/",
+				DirPath:      keyDirPath(Key{Hostname: "go.googlesource.com", Name: "tools", RefKind: RefTag, Ref: "v0.1.0"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "go.googlesource.com",
+				Forge:        ForgeGitiles,
+				RawPath:      "/tools",
+				Path:         "go.mod",
+				Owner:        "",
+				Name:         "tools",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v0.1.0",
+				RefKind:      RefTag,
+				ArchiveUrl:   "https://go.googlesource.com/tools/+archive/refs/tags/v0.1.0.tar.gz",
+				FileUrl:      "https://go.googlesource.com/tools/+/refs/tags/v0.1.0/[PATH]?format=TEXT",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitiles Slashes Branch Name Single File",
+			url:    "https://chromium.googlesource.com/chromium/src/+/feature/x/docs/README.md",
+			branch: "feature/x",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://chromium.googlesource.com/chromium/src/+/refs/heads/feature/x/docs/README.md",
+				RawUrl:       "https://chromium.googlesource.com/chromium/src/+/feature/x/docs/README.md",
+				CloneUrl:     "https://chromium.googlesource.com/chromium/src",
+				RemoteUrl:    "https://chromium.googlesource.com/chromium/src",
+				QueryUrl:     "https://chromium.googlesource.com/chromium/src/+/refs/heads/feature/x/docs/",
+				DirPath:      keyDirPath(Key{Hostname: "chromium.googlesource.com", Name: "chromium/src", RefKind: RefBranch, Ref: "feature/x"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "chromium.googlesource.com",
+				Forge:        ForgeGitiles,
+				RawPath:      "/chromium/src",
+				Path:         "docs/README.md",
+				Owner:        "",
+				Name:         "chromium/src",
+				DummyBranch:  "gitd-branch",
+				Branch:       "feature/x",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://chromium.googlesource.com/chromium/src/+archive/refs/heads/feature/x.tar.gz",
+				FileUrl:      "https://chromium.googlesource.com/chromium/src/+/refs/heads/feature/x/[PATH]?format=TEXT",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitiles Commit Repository",
+			url:    "https://go.googlesource.com/tools/+/0123456789abcdef0123456789abcdef01234567",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://go.googlesource.com/tools/+/0123456789abcdef0123456789abcdef01234567",
+				RawUrl:       "https://go.googlesource.com/tools/+/0123456789abcdef0123456789abcdef01234567",
+				CloneUrl:     "https://go.googlesource.com/tools",
+				RemoteUrl:    "https://go.googlesource.com/tools",
+				QueryUrl:     "https://go.googlesource.com/tools/+/0123456789abcdef0123456789abcdef01234567/",
+				DirPath:      keyDirPath(Key{Hostname: "go.googlesource.com", Name: "tools", RefKind: RefCommit, Ref: "0123456789abcdef0123456789abcdef01234567"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "go.googlesource.com",
+				Forge:        ForgeGitiles,
+				RawPath:      "/tools",
+				Path:         "",
+				Owner:        "",
+				Name:         "tools",
+				DummyBranch:  "gitd-branch",
+				Branch:       "0123456789abcdef0123456789abcdef01234567",
+				RefKind:      RefCommit,
+				ArchiveUrl:   "https://go.googlesource.com/tools/+archive/0123456789abcdef0123456789abcdef01234567.tar.gz",
+				FileUrl:      "https://go.googlesource.com/tools/+/0123456789abcdef0123456789abcdef01234567/[PATH]?format=TEXT",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitiles Registered Hostname Repository",
+			url:    "https://gerrit.example.com/infra/tools/+/refs/heads/main/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://gerrit.example.com/infra/tools/+/refs/heads/main",
+				RawUrl:       "https://gerrit.example.com/infra/tools/+/refs/heads/main/",
+				CloneUrl:     "https://gerrit.example.com/infra/tools",
+				RemoteUrl:    "https://gerrit.example.com/infra/tools",
+				QueryUrl:     "https://gerrit.example.com/infra/tools/+/refs/heads/main/",
+				DirPath:      keyDirPath(Key{Hostname: "gerrit.example.com", Name: "infra/tools", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gerrit.example.com",
+				Forge:        ForgeGitiles,
+				RawPath:      "/infra/tools",
+				Path:         "",
+				Owner:        "",
+				Name:         "infra/tools",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://gerrit.example.com/infra/tools/+archive/refs/heads/main.tar.gz",
+				FileUrl:      "https://gerrit.example.com/infra/tools/+/refs/heads/main/[PATH]?format=TEXT",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitiles Not Valid Route",
+			url:    "https://go.googlesource.com/tools/+log/refs/heads/master",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://go.googlesource.com/tools/+log/refs/heads/master",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "go.googlesource.com",
+				Forge:       ForgeGitiles,
+				Path:        "",
+				Owner:       "",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+func TestGitRepository_GitilesSubFolder(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		sub          string
+		direction    Direction
+		wantPath     string
+		wantUrl      string
+		wantCloneUrl string
+	}{
+		{
+			name:         "Gitiles Sub Folder Down",
+			url:          "https://go.googlesource.com/tools/+/refs/heads/master/gopls",
+			sub:          "doc",
+			direction:    DirectionDown,
+			wantPath:     "gopls/doc",
+			wantUrl:      "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc",
+			wantCloneUrl: "https://go.googlesource.com/tools",
+		},
+		{
+			name:         "Gitiles Sub Folder Up",
+			url:          "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc",
+			sub:          "gopls",
+			direction:    DirectionUp,
+			wantPath:     "gopls",
+			wantUrl:      "https://go.googlesource.com/tools/+/refs/heads/master/gopls",
+			wantCloneUrl: "https://go.googlesource.com/tools",
+		},
+		{
+			name:         "Gitiles Sub Folder Root",
+			url:          "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc",
+			sub:          "root",
+			direction:    DirectionNone,
+			wantPath:     "",
+			wantUrl:      "https://go.googlesource.com/tools/+/refs/heads/master",
+			wantCloneUrl: "https://go.googlesource.com/tools",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGitRepository("", "", tt.url, "")
+			if err := r.Parse(tt.sub, tt.direction, ""); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+
+			// repository path stays, sub folder changes path only
+			want := NewGitRepository("", "", tt.wantUrl, "")
+			if err := want.Parse("", DirectionNone, ""); err != nil {
+				t.Fatalf("GitRepository.Parse(%q) error = %v", tt.wantUrl, err)
+			}
+			if r.Path != tt.wantPath || r.Url != tt.wantUrl || r.CloneUrl != tt.wantCloneUrl || r.RawPath != want.RawPath || r.ArchiveUrl != want.ArchiveUrl || r.QueryUrl != want.QueryUrl {
+				t.Errorf("GitRepository.Parse() = %#v, want %#v", r, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// gitlab.com provider, positional routes with subgroups
+type gitlabProvider struct {
+	GenericProvider
+}
+
+func (gitlabProvider) Forge() string {
+	return ForgeGitLab
+}
+
+func (gitlabProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "gitlab.com")
+}
+
+// Fixed: https://gitlab.com/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/tree/main/materials?ref_type=heads Loooonnngggg gitlab urls
+func (gitlabProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parsePositionalRoute(u, filename, true)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/tree|blob/[BRANCH]/[PATH]
+func (p gitlabProvider) BrowseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + escapePath(p.routePath(l))
+}
+
+// owners and names are case insensitive
+func (gitlabProvider) foldCase(l *Location) {
+	l.lowerOwnerName()
+}
+
+func (gitlabProvider) routePath(l *Location) string {
+	return l.getPositionalPath("")
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/-/archive/[BRANCH]/gitlab-[BRANCH].[EXT]
+func (gitlabProvider) ArchiveUrl(l *Location) string {
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/-/archive/" + l.Branch + "/gitlab-" + strings.ReplaceAll(l.Branch, "/", "-") + ".zip"
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/-/blob/[BRANCH]/[PATH]
+// https://gitlab.com/gitlab-org/gitlab/-/raw/dc-move-assignees-widget/.git-blame-ignore-revs
+func (gitlabProvider) FileUrl(l *Location, path string) string {
+	return "https://" + l.Hostname + "/" + l.Owner + "/" + l.Name + "/-/raw/" + l.Branch + "/" + path
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/-/tree/[BRANCH]/[PATH]
+func (gitlabProvider) QueryUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/tree/" + filepath.Join(l.Branch, path) + "/"
+}
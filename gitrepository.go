@@ -1,27 +1,47 @@
 package gitrepository
 
 import (
-	"errors"
 	"fmt"
-	"net/url"
+	"log/slog"
 	"path/filepath"
-	"regexp"
 	"strings"
 )
 
-// enums: download options
+// forges: git hosting software which decides url routes
 const (
-	DownloadNone        = -1
-	DownloadFullPackage = iota
-	DownloadPartialPackage
-	DownloadSingleFile
-	DownloadCustomPackage
+	ForgeGitHub      = "github"
+	ForgeGitLab      = "gitlab"
+	ForgeBitbucket   = "bitbucket"
+	ForgeGitea       = "gitea"
+	ForgeGitee       = "gitee"
+	ForgeAzureDevOps = "azure-devops"
+	ForgeForgejo     = "forgejo"
+
+	ForgeBitbucketServer = "bitbucket-server"
+	ForgeCodeCommit      = "codecommit"
+	ForgeGitiles         = "gitiles"
+	ForgeSourceHut       = "sourcehut"
+	ForgeCgit            = "cgit"
+	ForgeGitweb          = "gitweb"
+	ForgeHuggingFace     = "huggingface"
+	ForgeLaunchpad       = "launchpad"
+	ForgeGitCode         = "gitcode"
+	ForgePagure          = "pagure"
+	ForgeGogs            = "gogs"
 )
 
+// ref kinds: what the Branch field points at
 const (
-	DirectionNone = 0
-	DirectionUp   = iota
-	DirectionDown
+	RefBranch = "branch"
+	RefTag    = "tag"
+	RefCommit = "commit"
+)
+
+// repository types: hugging face hub repositories
+const (
+	RepoTypeModel   = "model"
+	RepoTypeDataset = "dataset"
+	RepoTypeSpace   = "space"
 )
 
 // git repository
@@ -30,9 +50,10 @@ type GitRepository struct {
 	SSID    string
 
 	debugMode bool
+	logger    *slog.Logger
 
-	Url       string // clean url after parse
-	RawUrl    string // user set this dirty url
+	Url       string // clean browse url generated after parse
+	RawUrl    string // user set this dirty url, parse removes gitlab /-/ only
 	CloneUrl  string
 	RemoteUrl string // remote url for git git@github.com:username/repo.git
 	QueryUrl  string // for search bar
@@ -40,20 +61,25 @@ type GitRepository struct {
 
 	IsFile bool
 
-	Protocol    string // https|ssh (scp-style - not supported yet)
+	Protocol    string // https|ssh
 	Scheme      string
 	Hostname    string
+	Forge       string // github|gitlab|bitbucket|gitea|gitee|azure-devops|forgejo|bitbucket-server|codecommit|gitiles|sourcehut|cgit|gitweb|huggingface|launchpad|gitcode|pagure|gogs - empty for unknown hosts
+	Region      string // aws region for codecommit
+	RepoType    string // model|dataset|space for hugging face
 	RawPath     string
 	Path        string // file or folder path in this repository for download
 	Owner       string
 	Name        string // repository name - repo
 	DummyBranch string // if branch name is empty, use this name
 	Branch      string
-	IsTagBranch bool // for gitea.com tag based url
+	RefKind     string // branch|tag|commit - empty if branch is empty
+	IsTagBranch bool   // for gitea.com tag based url
+	IsLfs       bool   // for gitea media url, file content lives in lfs
 
 	ArchiveUrl   string // download branch package
 	FileUrl      string // download from single file url
-	DownloadType int
+	DownloadType DownloadType
 }
 
 func NewGitRepository(tempDir, ssid, rawUrl, branch string) *GitRepository {
@@ -71,20 +97,26 @@ func NewGitRepository(tempDir, ssid, rawUrl, branch string) *GitRepository {
 		Protocol:     "",
 		Scheme:       "",
 		Hostname:     "",
+		Forge:        "",
+		Region:       "",
+		RepoType:     "",
 		RawPath:      "",
 		Path:         "",
 		Owner:        "",
 		Name:         "",
 		DummyBranch:  "gitd-branch",
 		Branch:       branch,
+		RefKind:      "",
 		IsTagBranch:  false,
+		IsLfs:        false,
 		ArchiveUrl:   "",
 		FileUrl:      "",
-		DownloadType: -1,
+		DownloadType: DownloadNone,
 	}
 }
 
-// activate debug mode
+// activate debug mode: parse events logged to stderr as text
+// use SetLogger for json or your own handler
 func (r *GitRepository) ActivateDebugMode() {
 	r.debugMode = true
 }
@@ -94,17 +126,82 @@ func (r *GitRepository) isDebugModeActive() bool {
 	return r.debugMode
 }
 
-// generate repository dir path
+// log parse events to logger, events are debug level
+func (r *GitRepository) SetLogger(logger *slog.Logger) {
+	r.logger = logger
+}
+
+// logger of parse, debug mode logs to stderr without logger
+func (r *GitRepository) getLogger() *slog.Logger {
+	if r.logger == nil && r.isDebugModeActive() {
+		return newDebugLogger()
+	}
+
+	return r.logger
+}
+
+// generate repository dir path, one directory per repository and ref
+// hash of key keeps hosts apart and slashes of branches out of the layout
 func (r *GitRepository) GetDirPath() string {
-	branch := r.DummyBranch
+	key := r.Key()
+	key.RefKind, key.Ref = r.RefKind, r.DummyBranch
 	if r.Branch != "" {
-		branch = r.Branch
+		key.Ref = r.Branch
+	}
+
+	return filepath.Join(r.TempDir, r.SSID, "repository", key.Hash())
+}
+
+// split full ref name to branch name and ref kind
+// refs/heads/main -> main, branch
+// refs/tags/v1.0.0 -> v1.0.0, tag
+// 0123456789abcdef0123456789abcdef01234567 -> commit
+func splitRef(ref string) (string, string) {
+	if branch, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+		return branch, RefBranch
+	}
+	if tag, ok := strings.CutPrefix(ref, "refs/tags/"); ok {
+		return tag, RefTag
+	}
+	if isCommitHash(ref) {
+		return ref, RefCommit
+	}
+
+	return ref, RefBranch
+}
+
+// full sha1 or sha256 commit hash
+func isCommitHash(ref string) bool {
+	if len(ref) != 40 && len(ref) != 64 {
+		return false
+	}
+
+	for _, c := range ref {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
 	}
 
-	return filepath.Join(r.TempDir, r.SSID, "repository", r.Owner, r.Name, branch)
+	return true
 }
 
-// only https url accepted
+// convert scp-style remote url to ssh url
+// git@github.com:<owner>/<repo>.git -> ssh://git@github.com/<owner>/<repo>.git
+func scpToSshUrl(rawUrl string) string {
+	if strings.Contains(rawUrl, "://") {
+		return rawUrl
+	}
+
+	at := strings.Index(rawUrl, "@")
+	colon := strings.Index(rawUrl, ":")
+	if at == -1 || colon < at {
+		return rawUrl
+	}
+
+	return "ssh://" + rawUrl[:colon] + "/" + rawUrl[colon+1:]
+}
+
+// https and ssh (scp-style too) urls accepted
 /*
 https://github.com/<owner>/<repo>
 https://github.com/<owner>/<repo>.git -> .git remove
@@ -135,6 +232,8 @@ https://gitea.com/<owner>/<repo>/src/branch/<branch>/internal/url/url.go#L20 ->
 https://gitea.com/<owner>/<repo>/src/tag/<branch>/internal/url/url.go#L20 -> #L20 removes
 https://gitea.com/<owner>/<repo>/src/branch/<branch>/internal/url/url.go?deneme=12&obaraks=noway#L20 -> ?deneme=12&obaraks=noway#L20 remove
 https://gitea.com/<owner>/<repo>/src/tag/<branch>/internal/url/url.go?deneme=12&obaraks=noway#L20 -> ?deneme=12&obaraks=noway#L20 remove
+https://codeberg.org/<owner>/<repo>/src/commit/<commit>/lib -> forgejo, folder
+https://codeberg.org/<owner>/<repo>/media/branch/<branch>/model.bin -> forgejo, lfs single file
 
 https://gitee.com/<owner>/<repo>
 https://gitee.com/<owner>/<repo>.git -> .git remove
@@ -142,358 +241,257 @@ https://gitee.com/<owner>/<repo>/tree/<branch>/lib -> folder
 https://gitee.com/<owner>/<repo>/blob/<branch>/lib/filesaver.min.js -> single file
 https://gitee.com/<owner>/<repo>/blob/<branch>/internal/url/url.go#L20 -> #L20 removes
 https://gitee.com/<owner>/<repo>/blob/<branch>/internal/url/url.go?deneme=12&obaraks=noway#L20 -> ?deneme=12&obaraks=noway#L20 remove
+https://gitee.com/<owner>/<repo>/releases/tag/<tag> -> tag
+https://gitee.com/<owner>/<repo>/repository/archive/<branch>.zip
+https://gitcode.com/<owner>/<repo>/blob/<branch>/lib/filesaver.min.js -> gitcode, single file
+
+https://pagure.io/<repo>/blob/<branch>/f/<path> -> pagure, f removes
+https://src.fedoraproject.org/<namespace>/<repo>/raw/<branch>/f/<path> -> pagure, single file
+https://pagure.io/fork/<user>/<repo> -> pagure fork, owner: fork/<user>
+https://try.gogs.io/<owner>/<repo>/src/<branch>/<path> -> gogs
+
+https://dev.azure.com/<organization>/<project>/_git/<repo>
+https://dev.azure.com/<organization>/<project>/_git/<repo>?path=/src/app&version=GB<branch> -> folder
+https://dev.azure.com/<organization>/<project>/_git/<repo>?path=/src/app/main.go&version=GT<tag> -> single file
+https://dev.azure.com/<organization>/<project>/_git/<repo>?path=/src/app&version=GC<commit> -> folder
+https://<organization>.visualstudio.com/<project>/_git/<repo>
+https://<organization>.visualstudio.com/DefaultCollection/<project>/_git/<repo> -> DefaultCollection remove
+git@ssh.dev.azure.com:v3/<organization>/<project>/<repo>
+
+https://<hostname>/projects/<KEY>/repos/<repo>/browse/cmd?at=refs%2Fheads%2F<branch> -> folder
+https://<hostname>/projects/<KEY>/repos/<repo>/browse/cmd/main.go?at=refs%2Ftags%2F<tag> -> single file
+https://<hostname>/projects/<KEY>/repos/<repo>/raw/cmd/main.go?at=<commit> -> single file
+https://<hostname>/users/<user>/repos/<repo>/browse -> personal repository, owner: ~<user>
+https://<hostname>/scm/<key>/<repo>.git
+ssh://git@<hostname>:7999/<key>/<repo>.git
+
+https://git-codecommit.<region>.amazonaws.com/v1/repos/<repo> -> no owner
+codecommit::<region>://<profile>@<repo>
+https://<region>.console.aws.amazon.com/codesuite/codecommit/repositories/<repo>/browse/refs/heads/<branch>/--/src/app?region=<region> -> folder
+
+https://go.googlesource.com/<repo>/+/refs/heads/<branch>/gopls/doc -> folder
+https://android.googlesource.com/<deep>/<repo>/+/<branch>/core/java/ -> short ref, folder
+
+https://git.sr.ht/~<owner>/<repo>/tree/<branch>/item/<path> -> item removes
+https://git.sr.ht/~<owner>/<repo>/blob/<branch>/<path> -> single file
+git@git.sr.ht:~<owner>/<repo>
+
+https://huggingface.co/<owner>/<model>/tree/<branch>/onnx -> model, folder
+https://huggingface.co/<owner>/<model>/resolve/<branch>/model.safetensors -> model, single file
+https://huggingface.co/datasets/<owner>/<dataset>/blob/<branch>/README.md -> dataset, single file
+https://huggingface.co/spaces/<owner>/<space> -> space
+
+https://git.kernel.org/pub/scm/<deep>/<repo>.git/tree/<path>?h=<branch>&id=<commit> -> cgit, folder
+https://git.kernel.org/pub/scm/<deep>/<repo>.git/plain/<path>?h=<branch> -> cgit, single file
+https://git.launchpad.net/~<owner>/<project>/+git/<repo>/tree/<path>?h=<branch> -> launchpad, owner: ~<owner>/<project>
+https://git.launchpad.net/<project>/plain/<path>?h=<branch> -> launchpad project default repository, single file
+https://git.savannah.gnu.org/gitweb/?p=<repo>.git;a=blob;f=<path>;hb=refs/heads/<branch> -> gitweb, single file
+
+git@github.com:<owner>/<repo>.git -> ssh://git@github.com/<owner>/<repo>.git
 
 Supported: https://github.com/cli/cli/tree/marwan/localcs/api -> branch: marwan/localcs -> how to split this?
 Fixed: https://gitlab.com/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/-/tree/main/materials?ref_type=heads Loooonnngggg gitlab urls
 
-TODO: Url and RawUrl are the same? Why?
+Url and RawUrl: RawUrl is user input (gitlab /-/ removes), Url is the browse url generated from parsed fields
+
+Parse is the compatibility layer of ParseURL: sub folder jumps and dir path for gitdownloadmanager service
 */
-func (r *GitRepository) Parse(sub string, direction int, filename string) error {
-
-	// first
-	re := regexp.MustCompile(`(?s)/-/`)
-	r.RawUrl = re.ReplaceAllString(r.RawUrl, "/")
-	//r.RawUrl = strings.Replace(r.RawUrl, "/-/", "/", 1) // fix: gitlab strange url
-	if r.isDebugModeActive() {
-		fmt.Println("raw url", r.RawUrl, "filename", filename)
+func (r *GitRepository) Parse(sub string, direction Direction, filename string) error {
+	if !direction.IsValid() {
+		return fmt.Errorf("not valid direction: %d", direction)
 	}
 
-	// has to download single file
-	// update raw url
-	if filename != "" {
-		re2 := regexp.MustCompile(`(?s)/tree/`)
-		r.RawUrl = re2.ReplaceAllString(r.RawUrl, "/blob/")
+	l, err := parseLocation(r.RawUrl, options{logger: r.getLogger(), branch: r.Branch, filename: filename})
 
-		// little fixed - we know this is file not directory
-		r.IsFile = true
-		if r.isDebugModeActive() {
-			fmt.Println("raw url", r.RawUrl, "filename", filename)
-		}
-	}
-
-	// parse url
-	u, err := url.Parse(r.RawUrl)
+	// raw url keeps cleaned url for old users, location raw url never changes
+	r.RawUrl = cleanRawUrl(r.RawUrl)
+	r.setLocation(&l)
 	if err != nil {
 		return err
 	}
 
-	// find protocol
-	r.Protocol = "https"
-
-	// set scheme
-	r.Scheme = u.Scheme
-
-	// set hostname - not host
-	r.Hostname = u.Hostname()
-
-	// set path before clear unwanted querystring, fragments
-	r.RawPath = filepath.Join(u.Path, filename)
-	r.RawPath = strings.Replace(r.RawPath, u.RawFragment, "", 1)
-	r.RawPath = strings.Replace(r.RawPath, u.RawQuery, "", 1)
-	//r.RawPath = strings.Replace(r.RawPath, "/-/", "/", 1) // fix: gitlab strange url
-
-	// little fix - file recheck
-	if !r.IsFile {
-		r.IsFile = !strings.HasSuffix(r.RawUrl, "/") // only useful for bitbucket.org url
-	}
-
-	r.RawPath = strings.TrimSuffix(r.RawPath, "/") // remove last slashes
-
-	if r.isDebugModeActive() {
-		fmt.Println("raw path", r.RawPath)
-	}
-
-	// repeater counter
-	repeater := strings.Count(r.RawPath, "/")
-	if r.isDebugModeActive() {
-		fmt.Println("repeater", repeater)
-	}
-	if repeater < 2 {
-		return errors.New("not valid git url")
-	}
-
-	// multi slashes branch name
-	branchNameRepeater := 0
-	if r.Branch != "" {
-		branchNameRepeater = strings.Count(r.Branch, "/")
-	}
-
-	// n[1] = owner, n[2] = repo, n[3] = tree|blob, n[4] = branch, n[5] = ../../../...
-	nStart := 6
-	if r.Hostname == "gitea.com" {
-		nStart++
-	}
-	n := strings.SplitN(r.RawPath, "/", nStart+branchNameRepeater) // fixed n times all urls
-	if r.Hostname == "gitlab.com" /*&& r.RawUrl == "https://gitlab.com/era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025/practical-data-consumption-workshop/tree/main/materials?ref_type=heads"*/ {
-		m := strings.Split(r.RawPath, "/")
-		var splitPoint int
-		for i, segment := range m {
-			if segment == "tree" {
-				splitPoint = i
-				break
-			}
-		}
-
-		if splitPoint >= 4 {
-			// detect looonnnngggg folder urls
-			n = []string{
-				"",
-				strings.Join(m[1:splitPoint-1], "/"), // "era-europa-eu/public/interoperable-data-programme/era-ontology/rail-data-forum-2025", // owner
-				m[splitPoint-1],                      // "practical-data-consumption-workshop",                                                 // name
-				m[splitPoint],                        // "tree",                                                                                // type blob|tree|src
-				m[splitPoint+1],                      // "main",                                                                                // branch
-				strings.Join(m[splitPoint+2:], "/"),  // "materials",
-			}
-			if r.isDebugModeActive() {
-				fmt.Println("gitlab looonnnggg url:", n)
-			}
-
-		}
-	}
-	r.Owner = n[1]
-	r.Name = n[2]
-
-	if r.isDebugModeActive() {
-		fmt.Println("split n:", n, "branchNameRepeater", branchNameRepeater, "sub", sub)
-	}
-
-	if strings.HasSuffix(r.Name, ".git") {
-		r.Name = strings.Replace(r.Name, ".git", "", 1)
-		r.RawPath = strings.Replace(r.RawPath, ".git", "", 1)
-	}
-
-	if repeater >= 3 {
-		if n[3] == "blob" || n[3] == "tree" || n[3] == "src" {
-			if branchNameRepeater > 0 {
-				// branch name contains slash
-				if r.Hostname == "gitea.com" {
-					if len(n) > (5 + branchNameRepeater + 1) {
-						r.Path = n[5+branchNameRepeater+1]
-					}
-				} else {
-					if len(n) > (4 + branchNameRepeater + 1) {
-						r.Path = n[4+branchNameRepeater+1]
-					}
-				}
-			} else {
-				if r.Hostname == "gitea.com" {
-					if n[4] == "tag" {
-						r.IsTagBranch = true
-					}
-
-					r.Branch = n[5]
-					if len(n) > 6 {
-						r.Path = n[6]
-					}
-				} else {
-					r.Branch = n[4]
-					if len(n) > 5 {
-						r.Path = n[5]
-					}
-				}
-			}
-
-			// Bug and TODO
-			// Bitbucket.org url has src not tree or blob.
-			// Gitea.com url has src not tree or blob.
-			// if url not slashes, after download system failed because IsFile value not correct
-			// r.IsFile = !strings.HasSuffix(r.Path, "/")
-			/*if r.Hostname == "gitea.com" {
-				r.IsFile = false
-			} else*/
-			switch n[3] {
-			case "tree":
-				r.IsFile = false
-			case "blob":
-				r.IsFile = true
-			}
-		} else {
-			return errors.New("not valid git branch")
-		}
-	} else {
-		r.IsFile = false
-	}
-
 	// sub folder calculation for jump between folders
+	// raw path follows path only for positional routes, other providers keep repository path in it
+	positional := l.hasPositionalRawPath()
 	if sub == "root" {
 		// clone url must be return: jump to root folder
-		if r.Path != "" {
-			r.RawPath = strings.Replace(r.RawPath, r.Path, "", 1)
-			r.Path = ""
+		if l.Path != "" {
+			if positional {
+				l.RawPath = strings.Replace(l.RawPath, l.Path, "", 1)
+			}
+			l.Path = ""
+			l.build()
 		}
 	} else if sub != "" {
-		if r.Path != "" {
+		if l.Path != "" {
 			index := -1
 			if direction == DirectionUp {
-				if strings.Count(r.Path, sub) == 1 {
-					index = strings.LastIndex(r.Path, sub)
+				if strings.Count(l.Path, sub) == 1 {
+					index = strings.LastIndex(l.Path, sub)
 				} else {
-					index = strings.Index(r.Path, sub)
+					index = strings.Index(l.Path, sub)
 				}
 			}
 
 			if index == -1 {
-				r.Path = filepath.Join(r.Path, sub)
-				r.RawPath = filepath.Join(r.RawPath, sub)
+				l.Path = filepath.Join(l.Path, sub)
+				if positional {
+					l.RawPath = filepath.Join(l.RawPath, sub)
+				}
 			} else {
-				r.Path = r.Path[0 : index+len(sub)]
+				l.Path = l.Path[0 : index+len(sub)]
 
-				rawIndex := strings.Index(r.RawPath, sub)
-				r.RawPath = r.RawPath[0 : rawIndex+len(sub)]
+				if positional {
+					rawIndex := strings.Index(l.RawPath, sub)
+					l.RawPath = l.RawPath[0 : rawIndex+len(sub)]
+				}
 			}
 		} else {
-			r.Path = sub
-			r.RawPath = filepath.Join(r.RawPath, r.Path)
+			l.Path = sub
+			if positional {
+				l.RawPath = filepath.Join(l.RawPath, l.Path)
+			}
 		}
+		l.build()
 	}
-
-	// generate real url
-	r.CloneUrl = r.Scheme + "://" + r.Hostname + "/" + r.Owner + "/" + r.Name + ".git"
-	r.RemoteUrl = "git@" + r.Hostname + ":" + r.Owner + "/" + r.Name + ".git"
-	r.Url = r.Scheme + "://" + r.Hostname + r.RawPath
+	r.setLocation(&l)
+	r.setUrls(&l)
 
 	// generate pathDir
 	r.DirPath = r.GetDirPath()
 
-	// Generate Remote Url Addresses
-	r.ArchiveUrl = r.getArchiveUrl()
-	r.FileUrl = r.getFileUrl("[PATH]")
-	r.QueryUrl = r.GetQueryUrl(r.Path)
-
-	// Download Type
-	if r.CloneUrl == r.Url+".git" {
-		// full package
-		r.DownloadType = DownloadFullPackage
-	} else if r.Path == "" {
-		// full package
-		r.DownloadType = DownloadFullPackage
-	} else if r.IsFile {
-		// single file
-		r.DownloadType = DownloadSingleFile
-	} else {
-		// partial package
-		r.DownloadType = DownloadPartialPackage
+	if l.isLoggerActive() {
+		l.log(LogEventParsed, slog.String("url", redactUrl(r.Url)), slog.String("forge", r.Forge), slog.String("owner", r.Owner), slog.String("name", r.Name), slog.String("branch", r.Branch), slog.String("path", r.Path), slog.String("dir_path", r.DirPath))
 	}
+	return nil
+}
 
-	if r.isDebugModeActive() {
-		fmt.Printf("%#v\n", r)
+// location of repository, providers build urls from location
+func (r *GitRepository) location() *Location {
+	return &Location{
+		logger:      r.logger,
+		RawUrl:      r.RawUrl,
+		IsFile:      r.IsFile,
+		Protocol:    r.Protocol,
+		Scheme:      r.Scheme,
+		Hostname:    r.Hostname,
+		Forge:       r.Forge,
+		Region:      r.Region,
+		RepoType:    r.RepoType,
+		RawPath:     r.RawPath,
+		Path:        r.Path,
+		Owner:       r.Owner,
+		Name:        r.Name,
+		Branch:      r.Branch,
+		RefKind:     r.RefKind,
+		IsTagBranch: r.IsTagBranch,
+		IsLfs:       r.IsLfs,
 	}
-	return nil
 }
 
+// copy parsed location to repository, raw url stays
+func (r *GitRepository) setLocation(l *Location) {
+	r.IsFile = l.IsFile
+	r.Protocol = l.Protocol
+	r.Scheme = l.Scheme
+	r.Hostname = l.Hostname
+	r.Forge = l.Forge
+	r.Region = l.Region
+	r.RepoType = l.RepoType
+	r.RawPath = l.RawPath
+	r.Path = l.Path
+	r.Owner = l.Owner
+	r.Name = l.Name
+	r.Branch = l.Branch
+	r.RefKind = l.RefKind
+	r.IsTagBranch = l.IsTagBranch
+	r.IsLfs = l.IsLfs
+}
+
+// copy generated urls of location to repository
+func (r *GitRepository) setUrls(l *Location) {
+	r.Url = l.Url
+	r.CloneUrl = l.CloneUrl
+	r.RemoteUrl = l.RemoteUrl
+	r.QueryUrl = l.QueryUrl
+	r.ArchiveUrl = l.ArchiveUrl
+	r.FileUrl = l.FileUrl
+	r.DownloadType = l.DownloadType
+}
+
+// clone url without .git suffix, hosts and owners keep .git
 func (r *GitRepository) WithoutCloneUrl() string {
-	return strings.Replace(r.CloneUrl, ".git", "", 1)
+	return strings.TrimSuffix(r.CloneUrl, ".git")
+}
+
+// canonical browse url of repository, see Location.String
+func (r *GitRepository) String() string {
+	return r.location().String()
 }
 
+// change branch of repository, all urls and dir path regenerate
 func (r *GitRepository) UpdateBranch(branch string) {
-	r.Branch = branch
+	*r = *r.WithBranch(branch)
+}
 
-	// Generate Remote Url Addresses
-	r.ArchiveUrl = r.getArchiveUrl()
-	r.FileUrl = r.getFileUrl("[PATH]")
+// copy of repository on branch, path stays
+func (r *GitRepository) WithBranch(branch string) *GitRepository {
+	return r.withLocation(r.location().WithBranch(branch))
 }
 
-// generate archive url
-// Add: is multiple slash branch name, slashes removes
-func (r *GitRepository) getArchiveUrl() string {
-	switch r.Hostname {
-	case "gitlab.com":
-		// https://[HOSTNAME]/[OWNER]/[NAME]/-/archive/[BRANCH]/gitlab-[BRANCH].[EXT]
-		return fmt.Sprintf("https://%s/%s/%s/-/archive/%s/gitlab-%s.%s", r.Hostname, r.Owner, r.Name, r.Branch, strings.ReplaceAll(r.Branch, "/", "-"), "zip")
-	case "github.com":
-		// https://[HOSTNAME]/[OWNER]/[NAME]/archive/refs/heads/[BRANCH].[EXT]
-		// github archive url redirect always
-		// TODO: Redirect to https://codeload.github.com/[OWNER]/[NAME]/zip/refs/heads/[BRANCH]
-		return fmt.Sprintf("https://%s/%s/%s/archive/refs/heads/%s.%s", r.Hostname, r.Owner, r.Name, r.Branch, "zip")
-	case "bitbucket.org":
-		// https://[HOSTNAME]/[OWNER]/[NAME]/get/[BRANCH].[EXT]
-		return fmt.Sprintf("https://%s/%s/%s/get/%s.%s", r.Hostname, r.Owner, r.Name, r.Branch, "zip")
-	case "gitea.com":
-		// https://[HOSTNAME]/[OWNER]/[NAME]/archive/[BRANCH].[EXT]
-		// gitea archive url redirect always
-		return fmt.Sprintf("https://%s/%s/%s/archive/%s.%s", r.Hostname, r.Owner, r.Name, r.Branch, "zip")
-	case "gitee.com":
-		// Not supported right now
-		return ""
-	}
+// copy of repository on ref of kind branch|tag|commit, path stays
+func (r *GitRepository) WithRef(kind, name string) *GitRepository {
+	return r.withLocation(r.location().WithRef(kind, name))
+}
 
-	return ""
+// copy of repository on folder path
+func (r *GitRepository) WithPath(path string) *GitRepository {
+	return r.withLocation(r.location().WithPath(path))
 }
 
-// generate file url
-func (r *GitRepository) getFileUrl(path string) string {
-	switch r.Hostname {
-	case "gitlab.com":
-		// https://[HOSTNAME]/[OWNER]/[NAME]/-/blob/[BRANCH]/[PATH]
-		// https://gitlab.com/gitlab-org/gitlab/-/raw/dc-move-assignees-widget/.git-blame-ignore-revs
-		return fmt.Sprintf("https://%s/%s/%s/-/raw/%s/%s", r.Hostname, r.Owner, r.Name, r.Branch, path)
-	case "github.com":
-		// https://[HOSTNAME]/[OWNER]/[NAME]/blob/[BRANCH]/[PATH]
-		// https://raw.githubusercontent.com/101arrowz/fflate/master/.npmignore
-		return fmt.Sprintf("https://%s/%s/%s/%s/%s", "raw.githubusercontent.com", r.Owner, r.Name, r.Branch, path)
-	case "bitbucket.org":
-		// https://[HOSTNAME]/[OWNER]/[NAME]/raw/[BRANCH]/[PATH]
-		// https://bitbucket.org/micovery/sock-rpc/raw/v1.0.0/package.json
-		return fmt.Sprintf("https://%s/%s/%s/raw/%s/%s", r.Hostname, r.Owner, r.Name, r.Branch, path)
-	case "gitea.com":
-		// https://[HOSTNAME]/[OWNER]/[NAME]/raw/branch/[BRANCH]/[PATH]
-		// https://[HOSTNAME]/[OWNER]/[NAME]/raw/tag/[BRANCH]/[PATH]
-		// https://gitea.com/XIU2/TrackersListCollection/raw/branch/master/LICENSE
-		// https://gitea.com/XIU2/TrackersListCollection/raw/tag/20201211/LICENSE
-		branchOrTag := "branch"
-		if r.IsTagBranch {
-			branchOrTag = "tag"
-		}
-		return fmt.Sprintf("https://%s/%s/%s/raw/%s/%s/%s", r.Hostname, r.Owner, r.Name, branchOrTag, r.Branch, path)
-	case "gitee.com":
-		// https://[HOSTNAME]/[OWNER]/[NAME]/raw/[BRANCH]/[PATH]
-		// https://gitee.com/micovery/sock-rpc/raw/dev/package.json
-		// https://gitee.com/micovery/sock-rpc/raw/v1.0.0/package.json
-		return fmt.Sprintf("https://%s/%s/%s/raw/%s/%s", r.Hostname, r.Owner, r.Name, r.Branch, path)
-	}
+// copy of repository on single file path
+func (r *GitRepository) WithFile(path string) *GitRepository {
+	return r.withLocation(r.location().WithFile(path))
+}
 
-	return ""
+// normalized copy of repository, see Location.Normalize
+func (r *GitRepository) Normalize() *GitRepository {
+	return r.withLocation(r.location().Normalize())
 }
 
-// generate folder url
-func (r *GitRepository) GetQueryUrl(path string) string {
-	baseUrl := fmt.Sprintf("%s://%s/%s/%s", r.Scheme, r.Hostname, r.Owner, r.Name)
+// same repository, ref and path, see Location.Equal
+func (r *GitRepository) Equal(other *GitRepository) bool {
+	return r.location().Equal(*other.location())
+}
 
-	if r.Branch != "" {
-		if path != "" && r.IsFile {
-			index := strings.LastIndex(r.Path, "/")
-			if index != -1 {
-				path = r.Path[0:index]
-			} else {
-				path = ""
-			}
-		}
+// same repository, see Location.SameRepository
+func (r *GitRepository) SameRepository(other *GitRepository) bool {
+	return r.location().SameRepository(*other.location())
+}
 
-		switch r.Hostname {
-		case "gitlab.com":
-			// https://[HOSTNAME]/[OWNER]/[NAME]/-/blob/[BRANCH]/[PATH]
-			return fmt.Sprintf("%s/tree/%s/", baseUrl, filepath.Join(r.Branch, path))
-		case "github.com":
-			// https://[HOSTNAME]/[OWNER]/[NAME]/blob/[BRANCH]/[PATH]
-			return fmt.Sprintf("%s/tree/%s/", baseUrl, filepath.Join(r.Branch, path))
-		case "bitbucket.org":
-			// https://[HOSTNAME]/[OWNER]/[NAME]/src/[BRANCH]/[PATH]
-			return fmt.Sprintf("%s/src/%s/", baseUrl, filepath.Join(r.Branch, path))
-		case "gitea.com":
-			// https://[HOSTNAME]/[OWNER]/[NAME]/src/branch/[BRANCH]/[PATH]
-			// https://[HOSTNAME]/[OWNER]/[NAME]/src/tag/[TAG]/[PATH]
-			branchOrTag := "branch"
-			if r.IsTagBranch {
-				branchOrTag = "tag"
-			}
-			return fmt.Sprintf("%s/src/%s/%s/", baseUrl, branchOrTag, filepath.Join(r.Branch, path))
-		case "gitee.com":
-			// https://[HOSTNAME]/[OWNER]/[NAME]/blob/[BRANCH]/[PATH]
-			return fmt.Sprintf("%s/tree/%s/", baseUrl, filepath.Join(r.Branch, path))
-		}
-	}
+// repository key, see Location.Key
+func (r *GitRepository) Key() Key {
+	return r.location().Key()
+}
+
+// repository key with ref and path, see Location.RefKey
+func (r *GitRepository) RefKey() Key {
+	return r.location().RefKey()
+}
+
+// copy of repository with fields of location, raw url stays
+func (r *GitRepository) withLocation(l Location) *GitRepository {
+	repository := *r
+	repository.setLocation(&l)
+	repository.setUrls(&l)
+	repository.DirPath = repository.GetDirPath()
+
+	return &repository
+}
 
-	return baseUrl
+// generate folder url
+func (r *GitRepository) GetQueryUrl(path string) string {
+	return r.location().getQueryUrl(path)
 }
 
 // find real folder path
@@ -501,14 +499,5 @@ func (r *GitRepository) FindRealFolderPath(path string) string {
 	return r._findRealFolderPath(path)
 }
 func (r *GitRepository) _findRealFolderPath(path string) string {
-	if path != "" && r.IsFile {
-		index := strings.LastIndex(r.Path, "/")
-		if index != -1 {
-			path = r.Path[0:index]
-		} else {
-			path = ""
-		}
-	}
-
-	return path
+	return r.location().getFolderPath(path)
 }
@@ -0,0 +1,165 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// parse gitweb query, gitweb separates parameters with ; and &
+// p=<project>;a=<action>;f=<path>;hb=<branch>
+func parseGitwebQuery(rawQuery string) map[string]string {
+	query := map[string]string{}
+	for _, parameter := range strings.FieldsFunc(rawQuery, func(c rune) bool { return c == ';' || c == '&' }) {
+		key, value, _ := strings.Cut(parameter, "=")
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		query[key] = value
+	}
+
+	return query
+}
+
+// detect gitweb urls by project query
+func isGitwebUrl(u *url.URL) bool {
+	_, ok := parseGitwebQuery(u.RawQuery)["p"]
+	return ok
+}
+
+// gitweb provider, everything lives in query string
+type gitwebProvider struct {
+	GenericProvider
+}
+
+func (gitwebProvider) Forge() string {
+	return ForgeGitweb
+}
+
+func (gitwebProvider) Match(u *url.URL) bool {
+	return matchHostname(u, cgitHostnames...) && isGitwebUrl(u)
+}
+
+func (gitwebProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseGitwebRoute(u, filename)
+}
+
+// https://[HOSTNAME]/gitweb/?p=[OWNER]/[NAME].git
+func (gitwebProvider) BaseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + l.RawPath + "?p=" + l.getGitwebProject()
+}
+
+func (gitwebProvider) BrowseUrl(l *Location) string {
+	if l.IsFile {
+		return l.getGitwebActionUrl("blob", l.Path)
+	}
+	if l.Branch != "" || l.Path != "" {
+		return l.getGitwebActionUrl("tree", l.Path)
+	}
+	return l.getBaseUrl()
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME].git - gitweb does not clone, common http backend path
+func (gitwebProvider) CloneUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + "/" + l.getGitwebProject()
+}
+
+// git://[HOSTNAME]/[OWNER]/[NAME].git - git daemon
+func (gitwebProvider) RemoteUrl(l *Location) string {
+	return "git://" + l.Hostname + "/" + l.getGitwebProject()
+}
+
+// https://[HOSTNAME]/gitweb/?p=[OWNER]/[NAME].git;a=snapshot;h=[REF];sf=tgz
+func (gitwebProvider) ArchiveUrl(l *Location) string {
+	ref := l.getFullRef()
+	if ref == "" {
+		ref = "HEAD"
+	}
+	return l.getBaseUrl() + ";a=snapshot;h=" + ref + ";sf=tgz"
+}
+
+// https://[HOSTNAME]/gitweb/?p=[OWNER]/[NAME].git;a=blob_plain;f=[PATH];hb=[REF]
+func (gitwebProvider) FileUrl(l *Location, path string) string {
+	return l.getGitwebActionUrl("blob_plain", path)
+}
+
+// https://[HOSTNAME]/gitweb/?p=[OWNER]/[NAME].git;a=tree;f=[PATH];hb=[REF]
+func (gitwebProvider) QueryUrl(l *Location, path string) string {
+	return l.getGitwebActionUrl("tree", path)
+}
+
+// parse gitweb routes, repository lives in p query
+/*
+https://<hostname>/?p=<deep>/<repo>.git
+https://<hostname>/gitweb/?p=<deep>/<repo>.git;a=tree;f=<path>;hb=refs/heads/<branch> -> folder
+https://<hostname>/gitweb/?p=<deep>/<repo>.git;a=blob;f=<path>;hb=refs/heads/<branch> -> single file
+https://<hostname>/gitweb/?p=<deep>/<repo>.git;a=blob_plain;f=<path>;hb=<commit> -> single file
+https://<hostname>/gitweb/?p=<deep>/<repo>.git;a=shortlog;h=refs/heads/<branch>
+*/
+func (l *Location) parseGitwebRoute(u *url.URL, filename string) error {
+	query := parseGitwebQuery(u.RawQuery)
+
+	project := strings.Trim(query["p"], "/")
+	if project == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+	if index := strings.LastIndex(project, "/"); index != -1 {
+		l.Owner = project[:index]
+	}
+	l.Name = trimGitSuffix(filepath.Base(project))
+	if l.Name == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+
+	// gitweb script path: /, /gitweb/, /gitweb.cgi
+	l.RawPath = u.Path
+	if l.RawPath == "" {
+		l.RawPath = "/"
+	}
+
+	// hb=<branch> hash base first, h=<branch> only without file
+	ref := query["hb"]
+	if ref == "" && query["f"] == "" {
+		ref = query["h"]
+	}
+	if ref != "" && ref != "HEAD" {
+		l.Branch, l.RefKind = splitRef(ref)
+	}
+
+	l.Path = strings.Trim(filepath.Join(query["f"], filename), "/")
+
+	action := query["a"]
+	switch action {
+	case "blob", "blob_plain", "blame":
+		l.IsFile = l.Path != ""
+	case "tree":
+		l.IsFile = filename != ""
+	default:
+		l.IsFile = filename != "" || (l.Path != "" && filepath.Ext(l.Path) != "")
+	}
+
+	return nil
+}
+
+// generate gitweb action url
+// https://[HOSTNAME]/gitweb/?p=[OWNER]/[NAME].git;a=[ACTION];f=[PATH];hb=[REF]
+func (l *Location) getGitwebActionUrl(action, path string) string {
+	actionUrl := l.getBaseUrl() + ";a=" + action
+	if path != "" {
+		actionUrl += ";f=" + escapeQueryPath(path)
+	}
+	if l.Branch != "" {
+		actionUrl += ";hb=" + escapeQueryPath(l.getFullRef())
+	}
+
+	return actionUrl
+}
+
+// generate gitweb project path, gitweb projects are bare repositories
+func (l *Location) getGitwebProject() string {
+	if l.Owner == "" {
+		return l.Name + ".git"
+	}
+
+	return l.Owner + "/" + l.Name + ".git"
+}
@@ -0,0 +1,233 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_GitwebParse(t *testing.T) {
+	restoreRegistry(t)
+	RegisterForge("git.example.org", ForgeGitweb)
+
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Gitweb Repository",
+			url:    "https://git.savannah.gnu.org/gitweb/?p=emacs.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.savannah.gnu.org/gitweb/?p=emacs.git",
+				RawUrl:       "https://git.savannah.gnu.org/gitweb/?p=emacs.git",
+				CloneUrl:     "https://git.savannah.gnu.org/emacs.git",
+				RemoteUrl:    "git://git.savannah.gnu.org/emacs.git",
+				QueryUrl:     "https://git.savannah.gnu.org/gitweb/?p=emacs.git",
+				DirPath:      keyDirPath(Key{Hostname: "git.savannah.gnu.org", Name: "emacs", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.savannah.gnu.org",
+				Forge:        ForgeGitweb,
+				RawPath:      "/gitweb/",
+				Path:         "",
+				Owner:        "",
+				Name:         "emacs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=snapshot;h=HEAD;sf=tgz",
+				FileUrl:      "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=blob_plain;f=[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitweb Single File",
+			url:    "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=blob;f=lisp/simple.el;hb=refs/heads/master",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=blob;f=lisp/simple.el;hb=refs/heads/master",
+				RawUrl:       "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=blob;f=lisp/simple.el;hb=refs/heads/master",
+				CloneUrl:     "https://git.savannah.gnu.org/emacs.git",
+				RemoteUrl:    "git://git.savannah.gnu.org/emacs.git",
+				QueryUrl:     "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=tree;f=lisp;hb=refs/heads/master",
+				DirPath:      keyDirPath(Key{Hostname: "git.savannah.gnu.org", Name: "emacs", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.savannah.gnu.org",
+				Forge:        ForgeGitweb,
+				RawPath:      "/gitweb/",
+				Path:         "lisp/simple.el",
+				Owner:        "",
+				Name:         "emacs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=snapshot;h=refs/heads/master;sf=tgz",
+				FileUrl:      "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=blob_plain;f=[PATH];hb=refs/heads/master",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitweb Default Branch Folder",
+			url:    "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=tree;f=lisp;hb=HEAD",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=tree;f=lisp",
+				RawUrl:       "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=tree;f=lisp;hb=HEAD",
+				CloneUrl:     "https://git.savannah.gnu.org/emacs.git",
+				RemoteUrl:    "git://git.savannah.gnu.org/emacs.git",
+				QueryUrl:     "https://git.savannah.gnu.org/gitweb/?p=emacs.git",
+				DirPath:      keyDirPath(Key{Hostname: "git.savannah.gnu.org", Name: "emacs", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.savannah.gnu.org",
+				Forge:        ForgeGitweb,
+				RawPath:      "/gitweb/",
+				Path:         "lisp",
+				Owner:        "",
+				Name:         "emacs",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=snapshot;h=HEAD;sf=tgz",
+				FileUrl:      "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=blob_plain;f=[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitweb Deep Project Plain File",
+			url:    "https://git.example.org/?p=tools/build.git;a=blob_plain;f=Makefile;hb=0123456789abcdef0123456789abcdef01234567",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.example.org/?p=tools/build.git;a=blob;f=Makefile;hb=0123456789abcdef0123456789abcdef01234567",
+				RawUrl:       "https://git.example.org/?p=tools/build.git;a=blob_plain;f=Makefile;hb=0123456789abcdef0123456789abcdef01234567",
+				CloneUrl:     "https://git.example.org/tools/build.git",
+				RemoteUrl:    "git://git.example.org/tools/build.git",
+				QueryUrl:     "https://git.example.org/?p=tools/build.git;a=tree;hb=0123456789abcdef0123456789abcdef01234567",
+				DirPath:      keyDirPath(Key{Hostname: "git.example.org", Namespace: "tools", Name: "build", RefKind: RefCommit, Ref: "0123456789abcdef0123456789abcdef01234567"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.example.org",
+				Forge:        ForgeGitweb,
+				RawPath:      "/",
+				Path:         "Makefile",
+				Owner:        "tools",
+				Name:         "build",
+				DummyBranch:  "gitd-branch",
+				Branch:       "0123456789abcdef0123456789abcdef01234567",
+				RefKind:      RefCommit,
+				ArchiveUrl:   "https://git.example.org/?p=tools/build.git;a=snapshot;h=0123456789abcdef0123456789abcdef01234567;sf=tgz",
+				FileUrl:      "https://git.example.org/?p=tools/build.git;a=blob_plain;f=[PATH];hb=0123456789abcdef0123456789abcdef01234567",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitweb Shortlog Tag Repository",
+			url:    "https://git.example.org/?p=tools/build.git;a=shortlog;h=refs/tags/v1.0.0",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.example.org/?p=tools/build.git;a=tree;hb=refs/tags/v1.0.0",
+				RawUrl:       "https://git.example.org/?p=tools/build.git;a=shortlog;h=refs/tags/v1.0.0",
+				CloneUrl:     "https://git.example.org/tools/build.git",
+				RemoteUrl:    "git://git.example.org/tools/build.git",
+				QueryUrl:     "https://git.example.org/?p=tools/build.git;a=tree;hb=refs/tags/v1.0.0",
+				DirPath:      keyDirPath(Key{Hostname: "git.example.org", Namespace: "tools", Name: "build", RefKind: RefTag, Ref: "v1.0.0"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.example.org",
+				Forge:        ForgeGitweb,
+				RawPath:      "/",
+				Path:         "",
+				Owner:        "tools",
+				Name:         "build",
+				DummyBranch:  "gitd-branch",
+				Branch:       "v1.0.0",
+				RefKind:      RefTag,
+				ArchiveUrl:   "https://git.example.org/?p=tools/build.git;a=snapshot;h=refs/tags/v1.0.0;sf=tgz",
+				FileUrl:      "https://git.example.org/?p=tools/build.git;a=blob_plain;f=[PATH];hb=refs/tags/v1.0.0",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Gitweb Missing Project",
+			url:    "https://git.example.org/?a=tree;p=",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://git.example.org/?a=tree;p=",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "git.example.org",
+				Forge:       ForgeGitweb,
+				Path:        "",
+				Owner:       "",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// hugging face repository type prefixes, models have no prefix
+var huggingFaceRepoTypes = map[string]string{
+	"datasets": RepoTypeDataset,
+	"spaces":   RepoTypeSpace,
+}
+
+// hugging face hub provider, models, datasets and spaces
+type huggingFaceProvider struct {
+	GenericProvider
+}
+
+func (huggingFaceProvider) Forge() string {
+	return ForgeHuggingFace
+}
+
+func (huggingFaceProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "huggingface.co", "hf.co")
+}
+
+func (huggingFaceProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseHuggingFaceRoute(u, filename)
+}
+
+// https://huggingface.co/[TYPE]/[OWNER]/[NAME]
+func (huggingFaceProvider) BaseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + l.RawPath
+}
+
+// https://huggingface.co/[TYPE]/[OWNER]/[NAME]/tree|blob/[BRANCH]/[PATH]
+func (huggingFaceProvider) BrowseUrl(l *Location) string {
+	return l.getHuggingFaceTreeUrl(l.Path)
+}
+
+// https://huggingface.co/[TYPE]/[OWNER]/[NAME]
+func (huggingFaceProvider) CloneUrl(l *Location) string {
+	return l.getBaseUrl()
+}
+
+// git@hf.co:[TYPE]/[OWNER]/[NAME]
+func (huggingFaceProvider) RemoteUrl(l *Location) string {
+	return "git@hf.co:" + strings.TrimPrefix(l.RawPath, "/")
+}
+
+// Not supported: hugging face has no archive, clone instead
+func (huggingFaceProvider) ArchiveUrl(l *Location) string {
+	return ""
+}
+
+// https://huggingface.co/[TYPE]/[OWNER]/[NAME]/resolve/[BRANCH]/[PATH]
+// https://huggingface.co/openai-community/gpt2/resolve/main/config.json
+// resolve serves lfs files too
+func (huggingFaceProvider) FileUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/resolve/" + l.getHuggingFaceRef() + "/" + path
+}
+
+// https://huggingface.co/[TYPE]/[OWNER]/[NAME]/tree/[BRANCH]/[PATH]/
+func (huggingFaceProvider) QueryUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/tree/" + filepath.Join(l.getHuggingFaceRef(), path) + "/"
+}
+
+// parse hugging face hub routes, repository type decides the path prefix
+/*
+https://huggingface.co/<repo> -> model without owner
+https://huggingface.co/<owner>/<repo>
+https://huggingface.co/<owner>/<repo>/tree/<branch>/<path> -> folder
+https://huggingface.co/<owner>/<repo>/blob/<branch>/<path> -> single file
+https://huggingface.co/<owner>/<repo>/resolve/<branch>/<path> -> single file, lfs content
+https://huggingface.co/<owner>/<repo>/tree/refs%2Fpr%2F1/<path> -> pull request ref
+https://huggingface.co/datasets/<owner>/<repo>/tree/<branch>/<path>
+https://huggingface.co/spaces/<owner>/<repo>/blob/<branch>/<path>
+git@hf.co:datasets/<owner>/<repo>
+*/
+func (l *Location) parseHuggingFaceRoute(u *url.URL, filename string) error {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	// hf.co short and ssh hostname
+	l.Hostname = "huggingface.co"
+
+	l.RepoType = RepoTypeModel
+	prefix := ""
+	if repoType, ok := huggingFaceRepoTypes[segments[0]]; ok {
+		l.RepoType, prefix, segments = repoType, "/"+segments[0], segments[1:]
+	}
+
+	// <owner>/<repo>/<route>, old models have no owner
+	switch {
+	case len(segments) == 0 || segments[0] == "":
+		return newParseError(ErrMissingRepo, "")
+	case len(segments) == 1:
+		l.Name = segments[0]
+		segments = segments[1:]
+	default:
+		l.Owner, l.Name = segments[0], segments[1]
+		segments = segments[2:]
+	}
+	l.Name = trimGitSuffix(l.Name)
+	if l.Name == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+
+	l.RawPath = prefix + "/" + l.Name
+	if l.Owner != "" {
+		l.RawPath = prefix + "/" + l.Owner + "/" + l.Name
+	}
+
+	route, rest := "", segments
+	if len(rest) > 0 {
+		route, rest = rest[0], rest[1:]
+	}
+
+	l.traceRoute(route)
+	switch route {
+	case "":
+	case "tree", "blob", "resolve", "raw":
+		if len(rest) == 0 {
+			return newParseError(ErrInvalidRef, "")
+		}
+
+		// user set branch name first, refs/pr/<n> and refs/convert/<name> later
+		joined := strings.Join(rest, "/")
+		switch {
+		case l.Branch != "" && (joined == l.Branch || strings.HasPrefix(joined, l.Branch+"/")):
+		case rest[0] == "refs" && len(rest) >= 3:
+			l.Branch = strings.Join(rest[:3], "/")
+		default:
+			l.Branch = rest[0]
+		}
+		l.Path = strings.TrimPrefix(strings.TrimPrefix(joined, l.Branch), "/")
+	default:
+		return newParseError(ErrUnknownRoute, route)
+	}
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+
+	// tree route only serves folders
+	l.IsFile = filename != "" || (route != "tree" && l.Path != "")
+
+	return nil
+}
+
+// generate hugging face ref, main is the default branch of all repositories
+// slashes escape: refs/pr/1 -> refs%2Fpr%2F1
+func (l *Location) getHuggingFaceRef() string {
+	if l.Branch == "" {
+		return "main"
+	}
+
+	return url.PathEscape(l.Branch)
+}
+
+// generate hugging face web url
+// https://huggingface.co/[TYPE]/[OWNER]/[NAME]/tree|blob/[BRANCH]/[PATH]
+func (l *Location) getHuggingFaceTreeUrl(path string) string {
+	if l.Branch == "" && path == "" {
+		return l.getBaseUrl()
+	}
+
+	route := "tree"
+	if l.IsFile {
+		route = "blob"
+	}
+
+	return strings.TrimSuffix(l.getBaseUrl()+"/"+route+"/"+l.getHuggingFaceRef()+"/"+escapePath(path), "/")
+}
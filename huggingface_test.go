@@ -0,0 +1,480 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_HuggingFaceParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Hugging Face Model",
+			url:    "https://huggingface.co/openai-community/gpt2",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/openai-community/gpt2",
+				RawUrl:       "https://huggingface.co/openai-community/gpt2",
+				CloneUrl:     "https://huggingface.co/openai-community/gpt2",
+				RemoteUrl:    "git@hf.co:openai-community/gpt2",
+				QueryUrl:     "https://huggingface.co/openai-community/gpt2",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "model/openai-community", Name: "gpt2", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeModel,
+				RawPath:      "/openai-community/gpt2",
+				Path:         "",
+				Owner:        "openai-community",
+				Name:         "gpt2",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				FileUrl:      "https://huggingface.co/openai-community/gpt2/resolve/main/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Model Without Owner",
+			url:    "https://huggingface.co/gpt2",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/gpt2",
+				RawUrl:       "https://huggingface.co/gpt2",
+				CloneUrl:     "https://huggingface.co/gpt2",
+				RemoteUrl:    "git@hf.co:gpt2",
+				QueryUrl:     "https://huggingface.co/gpt2",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "model", Name: "gpt2", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeModel,
+				RawPath:      "/gpt2",
+				Path:         "",
+				Owner:        "",
+				Name:         "gpt2",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				FileUrl:      "https://huggingface.co/gpt2/resolve/main/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Model Single File",
+			url:    "https://huggingface.co/openai-community/gpt2/blob/main/config.json",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/openai-community/gpt2/blob/main/config.json",
+				RawUrl:       "https://huggingface.co/openai-community/gpt2/blob/main/config.json",
+				CloneUrl:     "https://huggingface.co/openai-community/gpt2",
+				RemoteUrl:    "git@hf.co:openai-community/gpt2",
+				QueryUrl:     "https://huggingface.co/openai-community/gpt2/tree/main/",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "model/openai-community", Name: "gpt2", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeModel,
+				RawPath:      "/openai-community/gpt2",
+				Path:         "config.json",
+				Owner:        "openai-community",
+				Name:         "gpt2",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				FileUrl:      "https://huggingface.co/openai-community/gpt2/resolve/main/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Model Folder",
+			url:    "https://huggingface.co/onnx-community/gpt2/tree/main/onnx",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/onnx-community/gpt2/tree/main/onnx",
+				RawUrl:       "https://huggingface.co/onnx-community/gpt2/tree/main/onnx",
+				CloneUrl:     "https://huggingface.co/onnx-community/gpt2",
+				RemoteUrl:    "git@hf.co:onnx-community/gpt2",
+				QueryUrl:     "https://huggingface.co/onnx-community/gpt2/tree/main/onnx/",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "model/onnx-community", Name: "gpt2", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeModel,
+				RawPath:      "/onnx-community/gpt2",
+				Path:         "onnx",
+				Owner:        "onnx-community",
+				Name:         "gpt2",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				FileUrl:      "https://huggingface.co/onnx-community/gpt2/resolve/main/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Model Resolve Single File",
+			url:    "https://huggingface.co/openai-community/gpt2/resolve/main/model.safetensors?download=true",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/openai-community/gpt2/blob/main/model.safetensors",
+				RawUrl:       "https://huggingface.co/openai-community/gpt2/resolve/main/model.safetensors?download=true",
+				CloneUrl:     "https://huggingface.co/openai-community/gpt2",
+				RemoteUrl:    "git@hf.co:openai-community/gpt2",
+				QueryUrl:     "https://huggingface.co/openai-community/gpt2/tree/main/",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "model/openai-community", Name: "gpt2", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeModel,
+				RawPath:      "/openai-community/gpt2",
+				Path:         "model.safetensors",
+				Owner:        "openai-community",
+				Name:         "gpt2",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				FileUrl:      "https://huggingface.co/openai-community/gpt2/resolve/main/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Pull Request Ref Folder",
+			url:    "https://huggingface.co/openai-community/gpt2/tree/refs%2Fpr%2F12/onnx",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/openai-community/gpt2/tree/refs%2Fpr%2F12/onnx",
+				RawUrl:       "https://huggingface.co/openai-community/gpt2/tree/refs%2Fpr%2F12/onnx",
+				CloneUrl:     "https://huggingface.co/openai-community/gpt2",
+				RemoteUrl:    "git@hf.co:openai-community/gpt2",
+				QueryUrl:     "https://huggingface.co/openai-community/gpt2/tree/refs%2Fpr%2F12/onnx/",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "model/openai-community", Name: "gpt2", RefKind: RefBranch, Ref: "refs/pr/12"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeModel,
+				RawPath:      "/openai-community/gpt2",
+				Path:         "onnx",
+				Owner:        "openai-community",
+				Name:         "gpt2",
+				DummyBranch:  "gitd-branch",
+				Branch:       "refs/pr/12",
+				RefKind:      RefBranch,
+				FileUrl:      "https://huggingface.co/openai-community/gpt2/resolve/refs%2Fpr%2F12/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Dataset",
+			url:    "https://huggingface.co/datasets/rajpurkar/squad",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/datasets/rajpurkar/squad",
+				RawUrl:       "https://huggingface.co/datasets/rajpurkar/squad",
+				CloneUrl:     "https://huggingface.co/datasets/rajpurkar/squad",
+				RemoteUrl:    "git@hf.co:datasets/rajpurkar/squad",
+				QueryUrl:     "https://huggingface.co/datasets/rajpurkar/squad",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "dataset/rajpurkar", Name: "squad", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeDataset,
+				RawPath:      "/datasets/rajpurkar/squad",
+				Path:         "",
+				Owner:        "rajpurkar",
+				Name:         "squad",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				FileUrl:      "https://huggingface.co/datasets/rajpurkar/squad/resolve/main/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Dataset Folder",
+			url:    "https://huggingface.co/datasets/rajpurkar/squad/tree/main/plain_text",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/datasets/rajpurkar/squad/tree/main/plain_text",
+				RawUrl:       "https://huggingface.co/datasets/rajpurkar/squad/tree/main/plain_text",
+				CloneUrl:     "https://huggingface.co/datasets/rajpurkar/squad",
+				RemoteUrl:    "git@hf.co:datasets/rajpurkar/squad",
+				QueryUrl:     "https://huggingface.co/datasets/rajpurkar/squad/tree/main/plain_text/",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "dataset/rajpurkar", Name: "squad", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeDataset,
+				RawPath:      "/datasets/rajpurkar/squad",
+				Path:         "plain_text",
+				Owner:        "rajpurkar",
+				Name:         "squad",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				FileUrl:      "https://huggingface.co/datasets/rajpurkar/squad/resolve/main/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Space Single File",
+			url:    "https://huggingface.co/spaces/gradio/hello_world/blob/main/app.py",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/spaces/gradio/hello_world/blob/main/app.py",
+				RawUrl:       "https://huggingface.co/spaces/gradio/hello_world/blob/main/app.py",
+				CloneUrl:     "https://huggingface.co/spaces/gradio/hello_world",
+				RemoteUrl:    "git@hf.co:spaces/gradio/hello_world",
+				QueryUrl:     "https://huggingface.co/spaces/gradio/hello_world/tree/main/",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "space/gradio", Name: "hello_world", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeSpace,
+				RawPath:      "/spaces/gradio/hello_world",
+				Path:         "app.py",
+				Owner:        "gradio",
+				Name:         "hello_world",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				FileUrl:      "https://huggingface.co/spaces/gradio/hello_world/resolve/main/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Slashes Branch Folder",
+			url:    "https://huggingface.co/org/model/tree/release/v1/onnx",
+			branch: "release/v1",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/org/model/tree/release%2Fv1/onnx",
+				RawUrl:       "https://huggingface.co/org/model/tree/release/v1/onnx",
+				CloneUrl:     "https://huggingface.co/org/model",
+				RemoteUrl:    "git@hf.co:org/model",
+				QueryUrl:     "https://huggingface.co/org/model/tree/release%2Fv1/onnx/",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "model/org", Name: "model", RefKind: RefBranch, Ref: "release/v1"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeModel,
+				RawPath:      "/org/model",
+				Path:         "onnx",
+				Owner:        "org",
+				Name:         "model",
+				DummyBranch:  "gitd-branch",
+				Branch:       "release/v1",
+				RefKind:      RefBranch,
+				FileUrl:      "https://huggingface.co/org/model/resolve/release%2Fv1/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Ssh Remote Url",
+			url:    "git@hf.co:datasets/rajpurkar/squad",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://huggingface.co/datasets/rajpurkar/squad",
+				RawUrl:       "git@hf.co:datasets/rajpurkar/squad",
+				CloneUrl:     "https://huggingface.co/datasets/rajpurkar/squad",
+				RemoteUrl:    "git@hf.co:datasets/rajpurkar/squad",
+				QueryUrl:     "https://huggingface.co/datasets/rajpurkar/squad",
+				DirPath:      keyDirPath(Key{Hostname: "huggingface.co", Namespace: "dataset/rajpurkar", Name: "squad", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "huggingface.co",
+				Forge:        ForgeHuggingFace,
+				RepoType:     RepoTypeDataset,
+				RawPath:      "/datasets/rajpurkar/squad",
+				Path:         "",
+				Owner:        "rajpurkar",
+				Name:         "squad",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				FileUrl:      "https://huggingface.co/datasets/rajpurkar/squad/resolve/main/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Hugging Face Unknown Route",
+			url:    "https://huggingface.co/openai-community/gpt2/discussions/1",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://huggingface.co/openai-community/gpt2/discussions/1",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "huggingface.co",
+				Forge:       ForgeHuggingFace,
+				RepoType:    RepoTypeModel,
+				RawPath:     "/openai-community/gpt2",
+				Path:        "",
+				Owner:       "openai-community",
+				Name:        "gpt2",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+func TestGitRepository_HuggingFaceSubFolder(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		sub          string
+		direction    Direction
+		wantPath     string
+		wantUrl      string
+		wantCloneUrl string
+	}{
+		{
+			name:         "Hugging Face Sub Folder Down",
+			url:          "https://huggingface.co/openai-community/gpt2/tree/main/onnx",
+			sub:          "docs",
+			direction:    DirectionDown,
+			wantPath:     "onnx/docs",
+			wantUrl:      "https://huggingface.co/openai-community/gpt2/tree/main/onnx/docs",
+			wantCloneUrl: "https://huggingface.co/openai-community/gpt2",
+		},
+		{
+			name:         "Hugging Face Sub Folder Up",
+			url:          "https://huggingface.co/openai-community/gpt2/tree/main/onnx/docs",
+			sub:          "onnx",
+			direction:    DirectionUp,
+			wantPath:     "onnx",
+			wantUrl:      "https://huggingface.co/openai-community/gpt2/tree/main/onnx",
+			wantCloneUrl: "https://huggingface.co/openai-community/gpt2",
+		},
+		{
+			name:         "Hugging Face Dataset Sub Folder Root",
+			url:          "https://huggingface.co/datasets/openai/gsm8k/tree/main/main",
+			sub:          "root",
+			direction:    DirectionNone,
+			wantPath:     "",
+			wantUrl:      "https://huggingface.co/datasets/openai/gsm8k/tree/main",
+			wantCloneUrl: "https://huggingface.co/datasets/openai/gsm8k",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGitRepository("", "", tt.url, "")
+			if err := r.Parse(tt.sub, tt.direction, ""); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+
+			// repository path stays, sub folder changes path only
+			want := NewGitRepository("", "", tt.wantUrl, "")
+			if err := want.Parse("", DirectionNone, ""); err != nil {
+				t.Fatalf("GitRepository.Parse(%q) error = %v", tt.wantUrl, err)
+			}
+			if r.Path != tt.wantPath || r.Url != tt.wantUrl || r.CloneUrl != tt.wantCloneUrl || r.RawPath != want.RawPath || r.ArchiveUrl != want.ArchiveUrl || r.QueryUrl != want.QueryUrl {
+				t.Errorf("GitRepository.Parse() = %#v, want %#v", r, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package gitrepository
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// version of json payloads, bumps on renamed or removed fields
+// new fields keep the version
+const JSONVersion = 1
+
+// json fields of location
+// generated urls and download type are written for readers, unmarshal regenerates them
+type locationJSON struct {
+	RawUrl string `json:"raw_url"`
+	IsFile bool   `json:"is_file"`
+
+	Protocol    string `json:"protocol"`
+	Scheme      string `json:"scheme"`
+	Hostname    string `json:"hostname"`
+	Forge       string `json:"forge"`
+	Region      string `json:"region"`
+	RepoType    string `json:"repo_type"`
+	RawPath     string `json:"raw_path"`
+	Path        string `json:"path"`
+	Owner       string `json:"owner"`
+	Name        string `json:"name"`
+	Branch      string `json:"branch"`
+	RefKind     string `json:"ref_kind"`
+	IsTagBranch bool   `json:"is_tag_branch"`
+	IsLfs       bool   `json:"is_lfs"`
+
+	Url          string       `json:"url"`
+	CloneUrl     string       `json:"clone_url"`
+	RemoteUrl    string       `json:"remote_url"`
+	QueryUrl     string       `json:"query_url"`
+	ArchiveUrl   string       `json:"archive_url"`
+	FileUrl      string       `json:"file_url"`
+	DownloadType DownloadType `json:"download_type"`
+}
+
+// json payload of location
+/*
+{
+  "version": 1,
+  "raw_url": "https://github.com/cli/cli/blob/trunk/go.mod",
+  "is_file": true,
+  "protocol": "https",
+  ...
+  "download_type": "single_file"
+}
+*/
+type locationPayload struct {
+	Version int `json:"version"`
+	locationJSON
+}
+
+// json payload of git repository, location fields are flat
+type gitRepositoryPayload struct {
+	Version     int    `json:"version"`
+	TempDir     string `json:"temp_dir"`
+	SSID        string `json:"ssid"`
+	DebugMode   bool   `json:"debug_mode"`
+	DummyBranch string `json:"dummy_branch"`
+	DirPath     string `json:"dir_path"`
+	locationJSON
+}
+
+// unparsed locations download nothing
+func newLocationJSON(l *Location) locationJSON {
+	downloadType := l.DownloadType
+	if l.Hostname == "" {
+		downloadType = DownloadNone
+	}
+
+	return locationJSON{
+		RawUrl:       l.RawUrl,
+		IsFile:       l.IsFile,
+		Protocol:     l.Protocol,
+		Scheme:       l.Scheme,
+		Hostname:     l.Hostname,
+		Forge:        l.Forge,
+		Region:       l.Region,
+		RepoType:     l.RepoType,
+		RawPath:      l.RawPath,
+		Path:         l.Path,
+		Owner:        l.Owner,
+		Name:         l.Name,
+		Branch:       l.Branch,
+		RefKind:      l.RefKind,
+		IsTagBranch:  l.IsTagBranch,
+		IsLfs:        l.IsLfs,
+		Url:          l.Url,
+		CloneUrl:     l.CloneUrl,
+		RemoteUrl:    l.RemoteUrl,
+		QueryUrl:     l.QueryUrl,
+		ArchiveUrl:   l.ArchiveUrl,
+		FileUrl:      l.FileUrl,
+		DownloadType: downloadType,
+	}
+}
+
+// location of json fields, urls regenerate from parsed fields
+// unparsed locations (no hostname) keep empty urls
+func (j *locationJSON) location() (Location, error) {
+	l := Location{
+		RawUrl:       j.RawUrl,
+		IsFile:       j.IsFile,
+		Protocol:     j.Protocol,
+		Scheme:       j.Scheme,
+		Hostname:     j.Hostname,
+		Forge:        j.Forge,
+		Region:       j.Region,
+		RepoType:     j.RepoType,
+		RawPath:      j.RawPath,
+		Path:         j.Path,
+		Owner:        j.Owner,
+		Name:         j.Name,
+		Branch:       j.Branch,
+		RefKind:      j.RefKind,
+		IsTagBranch:  j.IsTagBranch,
+		IsLfs:        j.IsLfs,
+		DownloadType: DownloadNone,
+	}
+
+	// providers registered in this process only
+	if l.Forge != "" && l.getProvider().Forge() != l.Forge {
+		return Location{}, fmt.Errorf("not valid forge: %q", l.Forge)
+	}
+	switch l.RefKind {
+	case "", RefBranch, RefTag, RefCommit:
+	default:
+		return Location{}, fmt.Errorf("not valid ref kind: %q", l.RefKind)
+	}
+
+	if l.Hostname != "" {
+		l.build()
+	}
+
+	return l, nil
+}
+
+// payloads of other versions rejected
+func checkJSONVersion(version int) error {
+	if version != JSONVersion {
+		return fmt.Errorf("not valid json version: %d", version)
+	}
+
+	return nil
+}
+
+func (l Location) MarshalJSON() ([]byte, error) {
+	return json.Marshal(locationPayload{Version: JSONVersion, locationJSON: newLocationJSON(&l)})
+}
+
+// urls and download type regenerate, no parse of raw url
+func (l *Location) UnmarshalJSON(data []byte) error {
+	payload := locationPayload{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	if err := checkJSONVersion(payload.Version); err != nil {
+		return err
+	}
+
+	location, err := payload.location()
+	if err != nil {
+		return err
+	}
+	*l = location
+
+	return nil
+}
+
+// logger is not written, set it again after unmarshal
+func (r GitRepository) MarshalJSON() ([]byte, error) {
+	l := r.location()
+	l.Url, l.CloneUrl, l.RemoteUrl, l.QueryUrl = r.Url, r.CloneUrl, r.RemoteUrl, r.QueryUrl
+	l.ArchiveUrl, l.FileUrl, l.DownloadType = r.ArchiveUrl, r.FileUrl, r.DownloadType
+
+	return json.Marshal(gitRepositoryPayload{
+		Version:      JSONVersion,
+		TempDir:      r.TempDir,
+		SSID:         r.SSID,
+		DebugMode:    r.debugMode,
+		DummyBranch:  r.DummyBranch,
+		DirPath:      r.DirPath,
+		locationJSON: newLocationJSON(l),
+	})
+}
+
+// urls, download type and dir path regenerate, no parse of raw url
+func (r *GitRepository) UnmarshalJSON(data []byte) error {
+	payload := gitRepositoryPayload{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	if err := checkJSONVersion(payload.Version); err != nil {
+		return err
+	}
+
+	l, err := payload.location()
+	if err != nil {
+		return err
+	}
+
+	repository := GitRepository{
+		TempDir:     payload.TempDir,
+		SSID:        payload.SSID,
+		debugMode:   payload.DebugMode,
+		RawUrl:      payload.RawUrl,
+		DummyBranch: payload.DummyBranch,
+	}
+	repository.setLocation(&l)
+	repository.setUrls(&l)
+	if l.Hostname != "" {
+		repository.DirPath = repository.GetDirPath()
+	}
+	*r = repository
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package gitrepository
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGitRepository_JSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		branch    string
+		sub       string
+		direction Direction
+		filename  string
+	}{
+		{name: "Github File", url: "https://github.com/cli/cli/blob/trunk/go.mod"},
+		{name: "Github Branch Hint", url: "https://github.com/cli/cli/tree/marwan/localcs/api", branch: "marwan/localcs"},
+		{name: "Gitlab Subgroups Sub Folder", url: "https://gitlab.com/gitlab-org/api/client-go/-/tree/main/examples", sub: "basic"},
+		{name: "Gitea Tag Filename", url: "https://gitea.com/gitea/tea/src/tag/v0.9.2/cmd", filename: "admin.go"},
+		{name: "Azure DevOps Folder", url: "https://dev.azure.com/org/project/_git/repo?path=/src/app&version=GBmain"},
+		{name: "Codecommit Repository", url: "https://git-codecommit.us-east-2.amazonaws.com/v1/repos/MyDemoRepo"},
+		{name: "Hugging Face Dataset", url: "https://huggingface.co/datasets/openai/gsm8k/blob/main/README.md"},
+		{name: "Unknown Host Repository", url: "https://git.example.com/owner/repo"},
+		{name: "Scp-Style Repository Root", url: "git@github.com:cli/cli.git", sub: "root"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGitRepository("/tmp", "ssid", tt.url, tt.branch)
+			if err := r.Parse(tt.sub, tt.direction, tt.filename); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+			r.ActivateDebugMode()
+
+			data, err := json.Marshal(r)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			got := &GitRepository{}
+			if err := json.Unmarshal(data, got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, r) {
+				t.Errorf("json.Unmarshal() = %#v, want %#v", got, r)
+			}
+
+			l, err := ParseURL(tt.url, BranchHint(tt.branch), Filename(tt.filename))
+			if err != nil {
+				t.Fatalf("ParseURL() error = %v", err)
+			}
+			data, err = json.Marshal(l)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			gotLocation := Location{}
+			if err := json.Unmarshal(data, &gotLocation); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotLocation, l) {
+				t.Errorf("json.Unmarshal() = %#v, want %#v", gotLocation, l)
+			}
+		})
+	}
+}
+
+func TestGitRepository_UnmarshalJSON(t *testing.T) {
+	r := NewGitRepository("", "", "https://github.com/cli/cli/tree/trunk/pkg", "")
+	if err := r.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+
+	// generated fields of payload rebuild from parsed fields
+	data := `{"version":1,"dummy_branch":"gitd-branch","raw_url":"https://github.com/cli/cli/tree/trunk/pkg","protocol":"https","scheme":"https","hostname":"github.com","forge":"github","raw_path":"/cli/cli/tree/trunk/pkg","path":"pkg","owner":"cli","name":"cli","branch":"trunk","ref_kind":"branch","url":"https://example.com","dir_path":"/etc","download_type":"single_file"}`
+	got := &GitRepository{}
+	if err := json.Unmarshal([]byte(data), got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("json.Unmarshal() = %#v, want %#v", got, r)
+	}
+
+	// unparsed repository keeps empty urls
+	r = NewGitRepository("", "", "https://github.com/cli/cli", "trunk")
+	encoded, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	got = &GitRepository{}
+	if err := json.Unmarshal(encoded, got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("json.Unmarshal() = %#v, want %#v", got, r)
+	}
+
+	for _, data := range []string{
+		`{"raw_url":"https://github.com/cli/cli"}`,
+		`{"version":2,"raw_url":"https://github.com/cli/cli"}`,
+		`{"version":1,"hostname":"github.com","forge":"gitgud","owner":"cli","name":"cli"}`,
+		`{"version":1,"hostname":"github.com","forge":"github","owner":"cli","name":"cli","branch":"trunk","ref_kind":"heads"}`,
+		`{"version":1,"hostname":"github.com","forge":"github","owner":"cli","name":"cli","download_type":"everything"}`,
+	} {
+		if err := json.Unmarshal([]byte(data), &GitRepository{}); err == nil {
+			t.Errorf("json.Unmarshal(%s) error = nil, want error", data)
+		}
+		if err := json.Unmarshal([]byte(data), &Location{}); err == nil {
+			t.Errorf("json.Unmarshal(%s) error = nil, want error", data)
+		}
+	}
+}
+
+func TestLocation_MarshalJSON(t *testing.T) {
+	l, err := ParseURL("https://gitea.com/gitea/tea/src/tag/v0.9.2/cmd")
+	if err != nil {
+		t.Fatalf("ParseURL() error = %v", err)
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`{"version":1,"raw_url":"https://gitea.com/gitea/tea/src/tag/v0.9.2/cmd",`,
+		`"branch":"v0.9.2","ref_kind":"tag","is_tag_branch":true,`,
+		`"download_type":"single_file"}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("json.Marshal() = %s, want %s", data, want)
+		}
+	}
+
+	// failed parse location
+	data, err = json.Marshal(Location{})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"download_type":"none"`) {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+}
@@ -0,0 +1,78 @@
+package gitrepository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// identity key of repository, ref and path for caches and storage layout
+// hostname, namespace and name are normalized, ref and path are case sensitive
+type Key struct {
+	Hostname  string
+	Namespace string // region and repo type first: us-east-1, dataset/openai, gitlab-org/api
+	Name      string
+	RefKind   string // branch|tag|commit - empty for repository keys
+	Ref       string
+	Path      string
+}
+
+// repository key: hostname, namespace and name
+// other forms of the same repository have the same key, see Location.Normalize
+func (l Location) Key() Key {
+	n := l.normalizeFields()
+
+	return n.repositoryKey()
+}
+
+// repository key with ref and path
+func (l Location) RefKey() Key {
+	n := l.normalizeFields()
+
+	key := n.repositoryKey()
+	key.RefKind, key.Ref, key.Path = n.RefKind, n.Branch, n.Path
+
+	return key
+}
+
+// key of normalized location fields
+func (l *Location) repositoryKey() Key {
+	namespace := []string{}
+	for _, segment := range []string{l.Region, l.RepoType, l.Owner} {
+		if segment != "" {
+			namespace = append(namespace, segment)
+		}
+	}
+
+	return Key{Hostname: l.Hostname, Namespace: strings.Join(namespace, "/"), Name: l.Name}
+}
+
+// readable key, refs escape their slashes
+// github.com/cli/cli, github.com/cli/cli@branch:marwan%2Flocalcs/api
+func (k Key) String() string {
+	s := k.Hostname + "/" + k.Namespace + "/" + k.Name
+	if k.Namespace == "" {
+		s = k.Hostname + "/" + k.Name
+	}
+	if k.Ref != "" || k.RefKind != "" {
+		s += "@" + k.RefKind + ":" + url.PathEscape(k.Ref)
+	}
+	if k.Path != "" {
+		s += "/" + k.Path
+	}
+
+	return s
+}
+
+// content safe key: hex sha256 of length prefixed fields
+// no slashes, dots or case of user input, safe as cache key and directory name
+func (k Key) Hash() string {
+	h := sha256.New()
+	for _, field := range []string{k.Hostname, k.Namespace, k.Name, k.RefKind, k.Ref, k.Path} {
+		h.Write([]byte(strconv.Itoa(len(field)) + ":" + field))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
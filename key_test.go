@@ -0,0 +1,180 @@
+package gitrepository
+
+import (
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// dir path of key without temp dir and session
+func keyDirPath(key Key) string {
+	return filepath.Join("repository", key.Hash())
+}
+
+func TestLocation_Key(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		branch     string
+		want       Key
+		wantString string
+		wantRef    string
+	}{
+		{
+			name:       "Github Repository",
+			url:        "http://www.github.com/CLI/cli.git/",
+			want:       Key{Hostname: "github.com", Namespace: "cli", Name: "cli"},
+			wantString: "github.com/cli/cli",
+			wantRef:    "github.com/cli/cli",
+		},
+		{
+			name:       "Gitlab Subgroups Folder",
+			url:        "https://gitlab.com/gitlab-org/api/client-go/-/tree/main/examples",
+			want:       Key{Hostname: "gitlab.com", Namespace: "gitlab-org/api", Name: "client-go"},
+			wantString: "gitlab.com/gitlab-org/api/client-go",
+			wantRef:    "gitlab.com/gitlab-org/api/client-go@branch:main/examples",
+		},
+		{
+			name:       "Gitlab Subgroups Ssh Remote",
+			url:        "git@gitlab.com:gitlab-org/api/client-go.git",
+			want:       Key{Hostname: "gitlab.com", Namespace: "gitlab-org/api", Name: "client-go"},
+			wantString: "gitlab.com/gitlab-org/api/client-go",
+			wantRef:    "gitlab.com/gitlab-org/api/client-go",
+		},
+		{
+			name:       "Github Slashes Branch Folder",
+			url:        "https://github.com/cli/cli/tree/marwan/localcs/api",
+			branch:     "marwan/localcs",
+			want:       Key{Hostname: "github.com", Namespace: "cli", Name: "cli"},
+			wantString: "github.com/cli/cli",
+			wantRef:    "github.com/cli/cli@branch:marwan%2Flocalcs/api",
+		},
+		{
+			name:       "Codecommit Region",
+			url:        "https://git-codecommit.us-east-2.amazonaws.com/v1/repos/MyDemoRepo",
+			want:       Key{Hostname: "git-codecommit.us-east-2.amazonaws.com", Namespace: "us-east-2", Name: "MyDemoRepo"},
+			wantString: "git-codecommit.us-east-2.amazonaws.com/us-east-2/MyDemoRepo",
+			wantRef:    "git-codecommit.us-east-2.amazonaws.com/us-east-2/MyDemoRepo",
+		},
+		{
+			name:       "Hugging Face Dataset",
+			url:        "https://huggingface.co/datasets/openai/gsm8k",
+			want:       Key{Hostname: "huggingface.co", Namespace: "dataset/openai", Name: "gsm8k"},
+			wantString: "huggingface.co/dataset/openai/gsm8k",
+			wantRef:    "huggingface.co/dataset/openai/gsm8k",
+		},
+		{
+			name:       "Pagure Without Owner",
+			url:        "https://pagure.io/pagure",
+			want:       Key{Hostname: "pagure.io", Name: "pagure"},
+			wantString: "pagure.io/pagure",
+			wantRef:    "pagure.io/pagure",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := ParseURL(tt.url, BranchHint(tt.branch))
+			if err != nil {
+				t.Fatalf("ParseURL(%q) error = %v", tt.url, err)
+			}
+
+			if got := l.Key(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Location.Key() = %#v, want %#v", got, tt.want)
+			}
+			if got := l.Key().String(); got != tt.wantString {
+				t.Errorf("Key.String() = %q, want %q", got, tt.wantString)
+			}
+			if got := l.RefKey().String(); got != tt.wantRef {
+				t.Errorf("Location.RefKey().String() = %q, want %q", got, tt.wantRef)
+			}
+		})
+	}
+}
+
+func TestKey_Hash(t *testing.T) {
+	parse := func(raw string) Location {
+		l, err := ParseURL(raw)
+		if err != nil {
+			t.Fatalf("ParseURL(%q) error = %v", raw, err)
+		}
+
+		return l
+	}
+
+	// hash is the storage layout, it must not change between versions
+	// sha256 of "10:github.com3:cli3:cli0:0:0:"
+	hash := parse("https://github.com/cli/cli").Key().Hash()
+	if want := "1d0c8cd8ccfe21c6428c474a0d65a9d84b6c3f43ce24a77f906521bed03d64e4"; hash != want {
+		t.Errorf("Key.Hash() = %q, want %q", hash, want)
+	}
+
+	// presentation of the same repository
+	for _, raw := range []string{"http://www.github.com/CLI/cli.git/", "git@github.com:cli/cli", "https://github.com/cli/cli.GIT"} {
+		if got := parse(raw).Key().Hash(); got != hash {
+			t.Errorf("Key.Hash() of %q = %q, want %q", raw, got, hash)
+		}
+	}
+
+	// same owner and name on other hosts
+	for _, raw := range []string{"https://gitlab.com/cli/cli", "https://bitbucket.org/cli/cli", "https://codeberg.org/cli/cli"} {
+		if got := parse(raw).Key().Hash(); got == hash {
+			t.Errorf("Key.Hash() of %q = %q, want other than github.com", raw, got)
+		}
+	}
+
+	// fields do not run into each other
+	a := Key{Hostname: "github.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "a/b", Path: "c"}
+	b := Key{Hostname: "github.com", Namespace: "cli", Name: "cli", RefKind: RefBranch, Ref: "a", Path: "b/c"}
+	if a.Hash() == b.Hash() {
+		t.Errorf("Key.Hash() of %#v and %#v = %q", a, b, a.Hash())
+	}
+}
+
+func TestGitRepository_GetDirPath(t *testing.T) {
+	github := NewGitRepository("/tmp", "ssid", "https://github.com/cli/cli/tree/marwan/localcs/api", "marwan/localcs")
+	if err := github.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+	gitlab := NewGitRepository("/tmp", "ssid", "https://gitlab.com/cli/cli/-/tree/marwan/localcs/api", "marwan/localcs")
+	if err := gitlab.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+
+	// one directory below repository, no slashes of branch
+	if !regexp.MustCompile(`^/tmp/ssid/repository/[0-9a-f]{64}$`).MatchString(github.DirPath) {
+		t.Errorf("GitRepository.DirPath = %q", github.DirPath)
+	}
+	if github.DirPath == gitlab.DirPath {
+		t.Errorf("GitRepository.DirPath of github and gitlab = %q", github.DirPath)
+	}
+
+	// folders of the same ref share the directory
+	folder := NewGitRepository("/tmp", "ssid", "https://github.com/cli/cli/tree/marwan/localcs/pkg", "marwan/localcs")
+	if err := folder.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+	if folder.DirPath != github.DirPath {
+		t.Errorf("GitRepository.DirPath = %q, want %q", folder.DirPath, github.DirPath)
+	}
+}
+
+func TestLocation_KeyUnknownHost(t *testing.T) {
+	l, err := ParseURL("https://git.example.io/owner/repo/src/v1.0/docs/")
+	if err != nil {
+		t.Fatalf("ParseURL() error = %v", err)
+	}
+
+	// unknown hosts keep parsed fields, a tag of other processes stays a tag
+	l.RefKind = RefTag
+	want := Key{Hostname: "git.example.io", Namespace: "owner", Name: "repo", RefKind: RefTag, Ref: "v1.0", Path: "docs"}
+	if got := l.RefKey(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Location.RefKey() = %#v, want %#v", got, want)
+	}
+
+	// known hosts without forge find their provider
+	github := Location{Scheme: "https", Hostname: "github.com", RawPath: "/cli/cli", Owner: "CLI", Name: "cli"}
+	if got, want := github.Key(), (Key{Hostname: "github.com", Namespace: "cli", Name: "cli"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Location.Key() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package gitrepository
+
+import (
+	"net/url"
+	"strings"
+)
+
+// launchpad provider, cgit urls with launchpad namespaces
+type launchpadProvider struct {
+	cgitProvider
+}
+
+func (launchpadProvider) Forge() string {
+	return ForgeLaunchpad
+}
+
+// lp:<project> launchpad shortcut urls
+func (launchpadProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "git.launchpad.net", "code.launchpad.net") || u.Scheme == "lp"
+}
+
+func (launchpadProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseLaunchpadRoute(u, filename)
+}
+
+// git+ssh://git.launchpad.net/[OWNER]/+git/[NAME]
+func (launchpadProvider) RemoteUrl(l *Location) string {
+	return "git+ssh://" + l.Hostname + l.RawPath
+}
+
+// parse launchpad routes, git.launchpad.net is cgit with launchpad namespaces
+/*
+https://git.launchpad.net/<project> -> project default repository, no owner
+https://git.launchpad.net/~<owner>/<project> -> owner default repository of project, owner: ~<owner>
+https://git.launchpad.net/~<owner>/<project>/+git/<repo> -> owner: ~<owner>/<project>
+https://git.launchpad.net/~<owner>/+git/<repo> -> personal repository, owner: ~<owner>
+https://git.launchpad.net/~<owner>/<distribution>/+source/<package>/+git/<repo> -> owner: ~<owner>/<distribution>/+source/<package>
+https://git.launchpad.net/<distribution>/+source/<package> -> package default repository, owner: <distribution>/+source
+https://git.launchpad.net/~<owner>/<project>/+git/<repo>/tree/<path>?h=<branch> -> cgit routes
+https://code.launchpad.net/~<owner>/<project>/+git/<repo> -> code browser, same namespaces
+git+ssh://git.launchpad.net/~<owner>/<project>/+git/<repo>
+lp:~<owner>/<project>/+git/<repo> -> lp: shortcut of git config
+*/
+func (l *Location) parseLaunchpadRoute(u *url.URL, filename string) error {
+	path := u.Path
+	if u.Scheme == "lp" {
+		// lp:<project>, opaque url
+		path = u.Opaque
+		l.Scheme = "https"
+	}
+
+	// web, ssh and lp: urls point the same cgit
+	l.Hostname = "git.launchpad.net"
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case segments[0] == "":
+		return newParseError(ErrMissingRepo, "")
+	case segments[0] == "~":
+		return newParseError(ErrMissingOwner, segments[0])
+	case strings.HasPrefix(segments[0], "+"):
+		return newParseError(ErrUnknownRoute, segments[0])
+	}
+
+	// repository path length of namespace: named repositories end after +git/<repo>
+	index := 0
+	for i, segment := range segments {
+		if segment == "+git" {
+			index = i + 2
+			break
+		}
+	}
+	if index == 0 {
+		// default repositories: [~<owner>/]<project> or [~<owner>/]<distribution>/+source/<package>
+		if strings.HasPrefix(segments[0], "~") {
+			index++
+		}
+		if len(segments) > index+1 && segments[index+1] == "+source" {
+			index += 2
+		}
+		index++
+	}
+	if index > len(segments) {
+		return newParseError(ErrMissingRepo, "")
+	}
+	if strings.HasPrefix(segments[index-1], "+") {
+		return newParseError(ErrUnknownRoute, segments[index-1])
+	}
+
+	if err := l.parseCgitSegments(u, segments, index, filename); err != nil {
+		return err
+	}
+
+	// ~<owner>/<project>/+git -> ~<owner>/<project>
+	l.Owner = strings.TrimSuffix(l.Owner, "/+git")
+
+	return nil
+}
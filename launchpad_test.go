@@ -0,0 +1,448 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_LaunchpadParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Launchpad Project Default Repository",
+			url:    "https://git.launchpad.net/cloud-init",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/cloud-init",
+				RawUrl:       "https://git.launchpad.net/cloud-init",
+				CloneUrl:     "https://git.launchpad.net/cloud-init",
+				RemoteUrl:    "git+ssh://git.launchpad.net/cloud-init",
+				QueryUrl:     "https://git.launchpad.net/cloud-init",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Name: "cloud-init", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/cloud-init",
+				Path:         "",
+				Owner:        "",
+				Name:         "cloud-init",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.launchpad.net/cloud-init/snapshot/cloud-init-HEAD.tar.gz",
+				FileUrl:      "https://git.launchpad.net/cloud-init/plain/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Project Default Single File",
+			url:    "https://git.launchpad.net/cloud-init/plain/README.md?h=main",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/cloud-init/tree/README.md?h=main",
+				RawUrl:       "https://git.launchpad.net/cloud-init/plain/README.md?h=main",
+				CloneUrl:     "https://git.launchpad.net/cloud-init",
+				RemoteUrl:    "git+ssh://git.launchpad.net/cloud-init",
+				QueryUrl:     "https://git.launchpad.net/cloud-init/tree/?h=main",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Name: "cloud-init", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/cloud-init",
+				Path:         "README.md",
+				Owner:        "",
+				Name:         "cloud-init",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.launchpad.net/cloud-init/snapshot/cloud-init-main.tar.gz",
+				FileUrl:      "https://git.launchpad.net/cloud-init/plain/[PATH]?h=main",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Owner Default Repository",
+			url:    "https://git.launchpad.net/~ubuntu-core-dev/ubuntu-seeds",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/~ubuntu-core-dev/ubuntu-seeds",
+				RawUrl:       "https://git.launchpad.net/~ubuntu-core-dev/ubuntu-seeds",
+				CloneUrl:     "https://git.launchpad.net/~ubuntu-core-dev/ubuntu-seeds",
+				RemoteUrl:    "git+ssh://git.launchpad.net/~ubuntu-core-dev/ubuntu-seeds",
+				QueryUrl:     "https://git.launchpad.net/~ubuntu-core-dev/ubuntu-seeds",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Namespace: "~ubuntu-core-dev", Name: "ubuntu-seeds", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/~ubuntu-core-dev/ubuntu-seeds",
+				Path:         "",
+				Owner:        "~ubuntu-core-dev",
+				Name:         "ubuntu-seeds",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.launchpad.net/~ubuntu-core-dev/ubuntu-seeds/snapshot/ubuntu-seeds-HEAD.tar.gz",
+				FileUrl:      "https://git.launchpad.net/~ubuntu-core-dev/ubuntu-seeds/plain/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Project Named Repository Folder",
+			url:    "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/tree/doc/?h=24.1.x",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/tree/doc?h=24.1.x",
+				RawUrl:       "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/tree/doc/?h=24.1.x",
+				CloneUrl:     "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				RemoteUrl:    "git+ssh://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				QueryUrl:     "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/tree/doc?h=24.1.x",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Namespace: "~cloud-init-dev/cloud-init", Name: "cloud-init", RefKind: RefBranch, Ref: "24.1.x"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/~cloud-init-dev/cloud-init/+git/cloud-init",
+				Path:         "doc",
+				Owner:        "~cloud-init-dev/cloud-init",
+				Name:         "cloud-init",
+				DummyBranch:  "gitd-branch",
+				Branch:       "24.1.x",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/snapshot/cloud-init-24.1.x.tar.gz",
+				FileUrl:      "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/plain/[PATH]?h=24.1.x",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Personal Repository",
+			url:    "https://git.launchpad.net/~user/+git/dotfiles",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/~user/+git/dotfiles",
+				RawUrl:       "https://git.launchpad.net/~user/+git/dotfiles",
+				CloneUrl:     "https://git.launchpad.net/~user/+git/dotfiles",
+				RemoteUrl:    "git+ssh://git.launchpad.net/~user/+git/dotfiles",
+				QueryUrl:     "https://git.launchpad.net/~user/+git/dotfiles",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Namespace: "~user", Name: "dotfiles", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/~user/+git/dotfiles",
+				Path:         "",
+				Owner:        "~user",
+				Name:         "dotfiles",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.launchpad.net/~user/+git/dotfiles/snapshot/dotfiles-HEAD.tar.gz",
+				FileUrl:      "https://git.launchpad.net/~user/+git/dotfiles/plain/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Snapshot Url",
+			url:    "https://git.launchpad.net/cloud-init/snapshot/cloud-init-main.tar.gz",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/cloud-init/tree/?h=main",
+				RawUrl:       "https://git.launchpad.net/cloud-init/snapshot/cloud-init-main.tar.gz",
+				CloneUrl:     "https://git.launchpad.net/cloud-init",
+				RemoteUrl:    "git+ssh://git.launchpad.net/cloud-init",
+				QueryUrl:     "https://git.launchpad.net/cloud-init/tree/?h=main",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Name: "cloud-init", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/cloud-init",
+				Path:         "",
+				Owner:        "",
+				Name:         "cloud-init",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.launchpad.net/cloud-init/snapshot/cloud-init-main.tar.gz",
+				FileUrl:      "https://git.launchpad.net/cloud-init/plain/[PATH]?h=main",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Code Browser Url",
+			url:    "https://code.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				RawUrl:       "https://code.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				CloneUrl:     "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				RemoteUrl:    "git+ssh://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				QueryUrl:     "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Namespace: "~cloud-init-dev/cloud-init", Name: "cloud-init", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/~cloud-init-dev/cloud-init/+git/cloud-init",
+				Path:         "",
+				Owner:        "~cloud-init-dev/cloud-init",
+				Name:         "cloud-init",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/snapshot/cloud-init-HEAD.tar.gz",
+				FileUrl:      "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/plain/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Git Ssh Url",
+			url:    "git+ssh://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				RawUrl:       "git+ssh://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				CloneUrl:     "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				RemoteUrl:    "git+ssh://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				QueryUrl:     "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Namespace: "~cloud-init-dev/cloud-init", Name: "cloud-init", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/~cloud-init-dev/cloud-init/+git/cloud-init",
+				Path:         "",
+				Owner:        "~cloud-init-dev/cloud-init",
+				Name:         "cloud-init",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/snapshot/cloud-init-HEAD.tar.gz",
+				FileUrl:      "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/plain/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Git Ssh User Url",
+			url:    "git+ssh://user@git.launchpad.net/cloud-init",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/cloud-init",
+				RawUrl:       "git+ssh://user@git.launchpad.net/cloud-init",
+				CloneUrl:     "https://git.launchpad.net/cloud-init",
+				RemoteUrl:    "git+ssh://git.launchpad.net/cloud-init",
+				QueryUrl:     "https://git.launchpad.net/cloud-init",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Name: "cloud-init", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/cloud-init",
+				Path:         "",
+				Owner:        "",
+				Name:         "cloud-init",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.launchpad.net/cloud-init/snapshot/cloud-init-HEAD.tar.gz",
+				FileUrl:      "https://git.launchpad.net/cloud-init/plain/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Lp Shortcut Url",
+			url:    "lp:~cloud-init-dev/cloud-init/+git/cloud-init",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				RawUrl:       "lp:~cloud-init-dev/cloud-init/+git/cloud-init",
+				CloneUrl:     "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				RemoteUrl:    "git+ssh://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				QueryUrl:     "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Namespace: "~cloud-init-dev/cloud-init", Name: "cloud-init", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/~cloud-init-dev/cloud-init/+git/cloud-init",
+				Path:         "",
+				Owner:        "~cloud-init-dev/cloud-init",
+				Name:         "cloud-init",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/snapshot/cloud-init-HEAD.tar.gz",
+				FileUrl:      "https://git.launchpad.net/~cloud-init-dev/cloud-init/+git/cloud-init/plain/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Empty Owner",
+			url:    "https://git.launchpad.net/~/cloud-init",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://git.launchpad.net/~/cloud-init",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "git.launchpad.net",
+				Forge:       ForgeLaunchpad,
+				Path:        "",
+				Owner:       "",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Parse Launchpad Package Named Repository Folder",
+			url:    "https://git.launchpad.net/~ubuntu-kernel/ubuntu/+source/linux/+git/noble/tree/drivers/net/?h=master-next",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/~ubuntu-kernel/ubuntu/+source/linux/+git/noble/tree/drivers/net?h=master-next",
+				RawUrl:       "https://git.launchpad.net/~ubuntu-kernel/ubuntu/+source/linux/+git/noble/tree/drivers/net/?h=master-next",
+				CloneUrl:     "https://git.launchpad.net/~ubuntu-kernel/ubuntu/+source/linux/+git/noble",
+				RemoteUrl:    "git+ssh://git.launchpad.net/~ubuntu-kernel/ubuntu/+source/linux/+git/noble",
+				QueryUrl:     "https://git.launchpad.net/~ubuntu-kernel/ubuntu/+source/linux/+git/noble/tree/drivers/net?h=master-next",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Namespace: "~ubuntu-kernel/ubuntu/+source/linux", Name: "noble", RefKind: RefBranch, Ref: "master-next"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/~ubuntu-kernel/ubuntu/+source/linux/+git/noble",
+				Path:         "drivers/net",
+				Owner:        "~ubuntu-kernel/ubuntu/+source/linux",
+				Name:         "noble",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master-next",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.launchpad.net/~ubuntu-kernel/ubuntu/+source/linux/+git/noble/snapshot/noble-master-next.tar.gz",
+				FileUrl:      "https://git.launchpad.net/~ubuntu-kernel/ubuntu/+source/linux/+git/noble/plain/[PATH]?h=master-next",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Launchpad Package Default Repository",
+			url:    "https://git.launchpad.net/ubuntu/+source/hello",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.launchpad.net/ubuntu/+source/hello",
+				RawUrl:       "https://git.launchpad.net/ubuntu/+source/hello",
+				CloneUrl:     "https://git.launchpad.net/ubuntu/+source/hello",
+				RemoteUrl:    "git+ssh://git.launchpad.net/ubuntu/+source/hello",
+				QueryUrl:     "https://git.launchpad.net/ubuntu/+source/hello",
+				DirPath:      keyDirPath(Key{Hostname: "git.launchpad.net", Namespace: "ubuntu/+source", Name: "hello", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.launchpad.net",
+				Forge:        ForgeLaunchpad,
+				RawPath:      "/ubuntu/+source/hello",
+				Path:         "",
+				Owner:        "ubuntu/+source",
+				Name:         "hello",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.launchpad.net/ubuntu/+source/hello/snapshot/hello-HEAD.tar.gz",
+				FileUrl:      "https://git.launchpad.net/ubuntu/+source/hello/plain/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
@@ -0,0 +1,522 @@
+package gitrepository
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// repository location: what a git url points at in a git hosting software
+// providers parse url routes into location and build urls from location
+// location has no filesystem fields, copy it freely
+type Location struct {
+	logger *slog.Logger
+	trace  *explainTrace
+	mode   parseMode
+
+	RawUrl string // user set this dirty url, never changes
+	IsFile bool
+
+	Protocol    string // https|ssh
+	Scheme      string
+	Hostname    string
+	Forge       string // provider forge name - empty for unknown hosts
+	Region      string // aws region for codecommit
+	RepoType    string // model|dataset|space for hugging face
+	RawPath     string
+	Path        string // file or folder path in this repository for download
+	Owner       string
+	Name        string // repository name - repo
+	Branch      string
+	RefKind     string // branch|tag|commit - empty if branch is empty
+	IsTagBranch bool   // for gitea.com tag based url
+	IsLfs       bool   // for gitea media url, file content lives in lfs
+
+	Url          string // clean url after parse
+	CloneUrl     string
+	RemoteUrl    string // remote url for git git@github.com:username/repo.git
+	QueryUrl     string // for search bar
+	ArchiveUrl   string // download branch package
+	FileUrl      string // download from single file url
+	DownloadType DownloadType
+}
+
+// parse options
+type Option func(*options)
+
+type options struct {
+	logger      *slog.Logger
+	trace       *explainTrace
+	branch      string
+	filename    string
+	defaultHost string
+	mode        parseMode
+}
+
+// parse modes share the same route rules
+// strict fails where default mode guesses, lenient repairs input before rules
+type parseMode int
+
+const (
+	modeDefault parseMode = iota
+	modeStrict
+	modeLenient
+)
+
+// branch name hint, branch names with slashes can not be split from url paths
+func BranchHint(branch string) Option {
+	return func(o *options) {
+		o.branch = branch
+	}
+}
+
+// user downloads only this file of the folder url
+func Filename(filename string) Option {
+	return func(o *options) {
+		o.filename = filename
+	}
+}
+
+// hostname of urls without hostname: <owner>/<repo> -> https://<hostname>/<owner>/<repo>
+func DefaultHost(hostname string) Option {
+	return func(o *options) {
+		o.defaultHost = hostname
+	}
+}
+
+// fail on unknown hosts instead of generic owner/name routes
+// fail on route keywords without ref, tree|blob|src without branch
+func Strict() Option {
+	return func(o *options) {
+		o.mode = modeStrict
+	}
+}
+
+// repair pasted urls before parse: surrounding quotes and angle brackets,
+// trailing punctuation, missing scheme and www. prefix
+func Lenient() Option {
+	return func(o *options) {
+		o.mode = modeLenient
+	}
+}
+
+// parse git url of any supported git hosting software
+/*
+ParseURL("https://github.com/cli/cli/tree/trunk/pkg")
+ParseURL("https://github.com/cli/cli/tree/marwan/localcs/api", BranchHint("marwan/localcs"))
+ParseURL("https://github.com/cli/cli/tree/trunk/pkg", Filename("go.mod"))
+ParseURL("cli/cli", DefaultHost("github.com"))
+*/
+func ParseURL(raw string, opts ...Option) (Location, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	l, err := parseLocation(raw, o)
+	if err != nil {
+		return Location{}, err
+	}
+
+	return l, nil
+}
+
+// clean dirty url before parse
+// gitlab /-/ separator remove, route keywords change after split of segments
+func cleanRawUrl(raw string) string {
+	if strings.Contains(raw, "/-/") {
+		raw = strings.Replace(raw, "/-/", "/", 1)
+	}
+
+	return raw
+}
+
+// repair pasted url of lenient mode
+/*
+"https://github.com/cli/cli" -> https://github.com/cli/cli
+<https://github.com/cli/cli> -> https://github.com/cli/cli
+https://github.com/cli/cli). -> https://github.com/cli/cli
+github.com/cli/cli -> https://github.com/cli/cli
+*/
+func repairRawUrl(raw string) string {
+	for {
+		repaired := strings.TrimSpace(raw)
+		repaired = strings.TrimRight(repaired, ".,;:!?")
+		if len(repaired) >= 2 {
+			first, last := repaired[0], repaired[len(repaired)-1]
+			if (first == '"' || first == '\'' || first == '`') && last == first || first == '<' && last == '>' || first == '(' && last == ')' {
+				repaired = repaired[1 : len(repaired)-1]
+			}
+		}
+		// unmatched closing parenthesis of markdown links: (https://...)
+		if strings.HasSuffix(repaired, ")") && !strings.Contains(repaired, "(") {
+			repaired = strings.TrimSuffix(repaired, ")")
+		}
+		if repaired == raw {
+			break
+		}
+		raw = repaired
+	}
+
+	// <hostname>/<owner>/<repo> without scheme, scp-style urls have schemes
+	if !strings.Contains(raw, "://") && scpToSshUrl(raw) == raw {
+		hostname, _, _ := strings.Cut(raw, "/")
+		if strings.Contains(hostname, ".") {
+			raw = "https://" + raw
+		}
+	}
+
+	return raw
+}
+
+// parse location, location keeps parsed fields on errors
+// errors are *ParseError
+func parseLocation(raw string, o options) (Location, error) {
+	l, err := parseRoutes(raw, o)
+	if err != nil {
+		return l, wrapParseError(err, raw, l.Hostname)
+	}
+
+	return l, nil
+}
+
+// parse url and routes of provider
+func parseRoutes(raw string, o options) (Location, error) {
+	l := Location{
+		logger: o.logger,
+		trace:  o.trace,
+		mode:   o.mode,
+		RawUrl: raw,
+		Branch: o.branch,
+		// little fixed - we know this is file not directory
+		IsFile: o.filename != "",
+	}
+
+	// lenient mode repairs pasted urls first, surrounding spaces never belong to urls
+	repairedUrl := strings.TrimSpace(raw)
+	if o.mode == modeLenient {
+		repairedUrl = repairRawUrl(repairedUrl)
+		if l.isExplaining() && repairedUrl != raw {
+			l.explain(ExplainStep{Rule: "lenient repair", Decision: redactUrl(repairedUrl), Evidence: "quotes, brackets, trailing punctuation or missing scheme"})
+		}
+	}
+
+	rawUrl := cleanRawUrl(repairedUrl)
+	if l.isLoggerActive() {
+		l.log(LogEventParseUrl, slog.String("url", redactUrl(raw)), slog.String("filename", o.filename))
+	}
+	if l.isExplaining() && rawUrl != repairedUrl {
+		l.explain(ExplainStep{Rule: "clean url", Decision: redactUrl(rawUrl), Evidence: "gitlab /-/ separator removed"})
+	}
+	if l.isExplaining() && o.filename != "" {
+		l.explain(ExplainStep{Rule: "filename", Decision: "file", Evidence: "filename " + o.filename + " is set", Fields: []string{"is_file"}})
+	}
+
+	// parse url - scp-style remote urls converted to ssh urls
+	u, err := url.Parse(scpToSshUrl(rawUrl))
+	if err != nil {
+		return l, err
+	}
+
+	if l.isExplaining() && u.Scheme == "ssh" && !strings.Contains(rawUrl, "://") {
+		l.explain(ExplainStep{Rule: "scp-style url", Decision: redactUrl(u.String()), Evidence: "user@host: prefix without scheme"})
+	}
+
+	// <owner>/<repo> urls without hostname
+	if u.Scheme == "" && u.Host == "" && o.defaultHost != "" {
+		u, err = url.Parse("https://" + o.defaultHost + "/" + strings.TrimPrefix(rawUrl, "/"))
+		if err != nil {
+			return l, err
+		}
+		if l.isExplaining() {
+			l.explain(ExplainStep{Rule: "default host", Decision: redactUrl(u.String()), Evidence: "url has no scheme and hostname"})
+		}
+	}
+
+	// find protocol
+	l.Protocol = "https"
+
+	// set scheme
+	l.Scheme = u.Scheme
+
+	// ssh remote urls point the same repository of https web url
+	if u.Scheme == "ssh" || u.Scheme == "git+ssh" {
+		l.Protocol = "ssh"
+		l.Scheme = "https"
+	}
+
+	if l.isExplaining() {
+		step := ExplainStep{Rule: "protocol", Decision: l.Protocol, Evidence: "scheme " + strconv.Quote(u.Scheme), Fields: []string{"protocol", "scheme"}}
+		if l.Protocol == "ssh" {
+			step.Evidence += ", ssh remote urls point the same repository of https web url"
+		}
+		l.explain(step)
+	}
+
+	// www. prefix of pasted web urls
+	if o.mode == modeLenient && strings.HasPrefix(strings.ToLower(u.Host), "www.") {
+		u.Host = u.Host[len("www."):]
+		if l.isExplaining() {
+			l.explain(ExplainStep{Rule: "lenient repair", Decision: u.Host, Evidence: "www. prefix removed"})
+		}
+	}
+
+	// set hostname - not host
+	l.Hostname = u.Hostname()
+
+	// find git hosting software of hostname
+	provider := findProvider(u)
+	l.Forge = provider.Forge()
+	if l.isLoggerActive() {
+		l.log(LogEventHostMatch, slog.String("hostname", l.Hostname), slog.String("forge", l.Forge), slog.String("protocol", l.Protocol))
+	}
+	if l.isExplaining() {
+		l.explain(explainProviderMatch(u, provider))
+	}
+	if l.Forge == "" && l.Hostname == "" {
+		// <owner>/<repo> without default host, relative paths: no host to guess in any mode
+		return l, newParseError(ErrMissingHost, "")
+	}
+	if o.mode == modeStrict && l.Forge == "" {
+		return l, newParseError(ErrUnsupportedHost, l.Hostname)
+	}
+
+	// route parse: owner, name, branch, path
+	err = provider.ParseRoute(&l, u, o.filename)
+	if l.isExplaining() {
+		l.explainRoute(provider, err)
+	}
+	if err != nil {
+		return l, err
+	}
+	if l.Hostname == "" {
+		return l, newParseError(ErrMissingHost, "")
+	}
+
+	if l.Branch != "" && l.RefKind == "" {
+		l.RefKind = RefBranch
+		if l.isExplaining() {
+			l.explain(ExplainStep{Rule: "ref kind", Decision: RefBranch, Evidence: "url has no tag or commit marker", Rejected: []string{RefTag, RefCommit}, Fields: []string{"ref_kind"}})
+		}
+	}
+	if l.isLoggerActive() {
+		l.log(LogEventBranchSplit, slog.String("branch", l.Branch), slog.String("ref_kind", l.RefKind), slog.Bool("branch_hint", o.branch != ""), slog.String("path", l.Path))
+	}
+
+	l.build()
+
+	return l, nil
+}
+
+// generate urls of location
+func (l *Location) build() {
+	provider := l.getProvider()
+
+	// generate real url
+	l.CloneUrl = provider.CloneUrl(l)
+	l.RemoteUrl = provider.RemoteUrl(l)
+	l.Url = provider.BrowseUrl(l)
+
+	// Generate Remote Url Addresses
+	l.ArchiveUrl = provider.ArchiveUrl(l)
+	l.FileUrl = provider.FileUrl(l, "[PATH]")
+	l.QueryUrl = l.getQueryUrl(l.Path)
+
+	// Download Type
+	reason := ""
+	if l.CloneUrl == l.Url+".git" {
+		// full package
+		l.DownloadType, reason = DownloadFullPackage, "clone url"
+	} else if l.Path == "" {
+		// full package
+		l.DownloadType, reason = DownloadFullPackage, "empty path"
+	} else if l.IsFile {
+		// single file
+		l.DownloadType, reason = DownloadSingleFile, "file path"
+	} else {
+		// partial package
+		l.DownloadType, reason = DownloadPartialPackage, "folder path"
+	}
+	if l.isLoggerActive() {
+		l.log(LogEventDownloadType, slog.String("download_type", l.DownloadType.String()), slog.String("reason", reason))
+	}
+	if l.isExplaining() {
+		l.explainDownloadType(reason)
+	}
+}
+
+// copy of location on branch, path stays
+// empty branch is the default branch of repository
+func (l Location) WithBranch(branch string) Location {
+	l.Branch, l.RefKind = branch, ""
+	if branch != "" {
+		l.RefKind = RefBranch
+	}
+	l.rebuild()
+
+	return l
+}
+
+// copy of location on ref of kind branch|tag|commit, path stays
+// empty kind finds kind of full ref names: refs/heads/main, refs/tags/v1.0.0, commit hashes
+func (l Location) WithRef(kind, name string) Location {
+	switch kind {
+	case RefBranch, RefTag, RefCommit:
+		l.Branch, l.RefKind = name, kind
+	default:
+		l.Branch, l.RefKind = splitRef(name)
+	}
+	if l.Branch == "" {
+		l.RefKind = ""
+	}
+	l.rebuild()
+
+	return l
+}
+
+// copy of location on folder path, empty path is repository root
+func (l Location) WithPath(path string) Location {
+	l.Path, l.IsFile = strings.Trim(path, "/"), false
+	l.rebuild()
+
+	return l
+}
+
+// copy of location on single file path
+func (l Location) WithFile(path string) Location {
+	l.Path = strings.Trim(path, "/")
+	l.IsFile = l.Path != ""
+	l.rebuild()
+
+	return l
+}
+
+// regenerate fields of changed branch or path: raw path, tag flag and urls
+func (l *Location) rebuild() {
+	provider := l.getProvider()
+	if _, ok := provider.(giteaProvider); ok {
+		l.IsTagBranch = l.RefKind == RefTag
+	}
+	switch builder := provider.(type) {
+	case routePathBuilder:
+		l.RawPath = builder.routePath(l)
+	case GenericProvider:
+		// unknown hosts, not providers embedding generic provider: they keep parsed raw path
+		// src routes stay, tree and blob follow file flag
+		route := l.getPositionalRoute()
+		if route != "src" {
+			route = ""
+		}
+		l.RawPath = l.getPositionalPath(route)
+	}
+
+	l.build()
+}
+
+// providers with browse urls which do not tell files give canonical urls of other routes
+type canonicalUrlBuilder interface {
+	canonicalUrl(l *Location) string
+}
+
+// canonical browse url of location: provider routes, blob for files and tree for folders
+// ref and path are escaped, folders of a ref end with slash (src routes tell folders by it)
+// ParseURL of it gives back the same location for web urls, empty for unparsed locations
+func (l Location) String() string {
+	if l.Hostname == "" {
+		return ""
+	}
+
+	provider := l.getProvider()
+	browseUrl := provider.BrowseUrl(&l)
+	if builder, ok := provider.(canonicalUrlBuilder); ok {
+		browseUrl = builder.canonicalUrl(&l)
+	}
+	if l.Branch != "" && !l.IsFile && !strings.ContainsAny(browseUrl, "?;") && !strings.HasSuffix(browseUrl, "/") {
+		browseUrl += "/"
+	}
+
+	return browseUrl
+}
+
+// canonical browse url, unparsed locations rejected
+func (l Location) MarshalText() ([]byte, error) {
+	if l.Hostname == "" {
+		return nil, fmt.Errorf("not valid location: empty hostname")
+	}
+
+	return []byte(l.String()), nil
+}
+
+// parse url text with default options
+func (l *Location) UnmarshalText(text []byte) error {
+	location, err := ParseURL(string(text))
+	if err != nil {
+		return err
+	}
+	*l = location
+
+	return nil
+}
+
+// generate repository web url without branch and path
+func (l *Location) getBaseUrl() string {
+	return l.getProvider().BaseUrl(l)
+}
+
+// generate folder url
+func (l *Location) getQueryUrl(path string) string {
+	if l.Branch != "" {
+		return l.getProvider().QueryUrl(l, l.getFolderPath(path))
+	}
+
+	return l.getBaseUrl()
+}
+
+// provider of location, generic provider for unknown hosts
+func (l *Location) getProvider() Provider {
+	return findProviderByForge(l.Forge)
+}
+
+// generate full ref name of branch
+// main, branch -> refs/heads/main
+// v1.0.0, tag -> refs/tags/v1.0.0
+// commit hashes stay the same
+func (l *Location) getFullRef() string {
+	if l.Branch == "" {
+		return ""
+	}
+
+	switch l.RefKind {
+	case RefTag:
+		return "refs/tags/" + l.Branch
+	case RefCommit:
+		return l.Branch
+	}
+
+	return "refs/heads/" + l.Branch
+}
+
+// folder of path, file names remove
+func (l *Location) getFolderPath(path string) string {
+	if path != "" && l.IsFile {
+		index := strings.LastIndex(l.Path, "/")
+		if index != -1 {
+			return l.Path[0:index]
+		}
+		return ""
+	}
+
+	return path
+}
+
+// raw path is the positional route path of branch and path, not the repository path
+func (l *Location) hasPositionalRawPath() bool {
+	switch l.getProvider().(type) {
+	case routePathBuilder, GenericProvider:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,424 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		opts    []Option
+		want    Location
+		wantErr bool
+	}{
+		{
+			name: "Parse Github Repository",
+			url:  "https://github.com/cli/cli",
+			opts: nil,
+			want: Location{
+				RawUrl:       "https://github.com/cli/cli",
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
+				RawPath:      "/cli/cli",
+				Path:         "",
+				Owner:        "cli",
+				Name:         "cli",
+				Branch:       "",
+				Url:          "https://github.com/cli/cli",
+				CloneUrl:     "https://github.com/cli/cli.git",
+				RemoteUrl:    "git@github.com:cli/cli.git",
+				QueryUrl:     "https://github.com/cli/cli",
+				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/.zip",
+				FileUrl:      "https://raw.githubusercontent.com/cli/cli//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Parse Branch Hint",
+			url:  "https://github.com/cli/cli/tree/marwan/localcs/api",
+			opts: []Option{BranchHint("marwan/localcs")},
+			want: Location{
+				RawUrl:       "https://github.com/cli/cli/tree/marwan/localcs/api",
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
+				RawPath:      "/cli/cli/tree/marwan/localcs/api",
+				Path:         "api",
+				Owner:        "cli",
+				Name:         "cli",
+				Branch:       "marwan/localcs",
+				RefKind:      RefBranch,
+				Url:          "https://github.com/cli/cli/tree/marwan/localcs/api",
+				CloneUrl:     "https://github.com/cli/cli.git",
+				RemoteUrl:    "git@github.com:cli/cli.git",
+				QueryUrl:     "https://github.com/cli/cli/tree/marwan/localcs/api/",
+				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/marwan/localcs.zip",
+				FileUrl:      "https://raw.githubusercontent.com/cli/cli/marwan/localcs/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Parse Filename Raw Url Stays",
+			url:  "https://gitlab.com/gitlab-org/gitlab/-/tree/master/app",
+			opts: []Option{Filename("README.md")},
+			want: Location{
+				RawUrl:       "https://gitlab.com/gitlab-org/gitlab/-/tree/master/app",
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "gitlab.com",
+				Forge:        ForgeGitLab,
+				RawPath:      "/gitlab-org/gitlab/blob/master/app/README.md",
+				Path:         "app/README.md",
+				Owner:        "gitlab-org",
+				Name:         "gitlab",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				Url:          "https://gitlab.com/gitlab-org/gitlab/blob/master/app/README.md",
+				CloneUrl:     "https://gitlab.com/gitlab-org/gitlab.git",
+				RemoteUrl:    "git@gitlab.com:gitlab-org/gitlab.git",
+				QueryUrl:     "https://gitlab.com/gitlab-org/gitlab/tree/master/app/",
+				ArchiveUrl:   "https://gitlab.com/gitlab-org/gitlab/-/archive/master/gitlab-master.zip",
+				FileUrl:      "https://gitlab.com/gitlab-org/gitlab/-/raw/master/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Parse Default Host",
+			url:  "cli/cli",
+			opts: []Option{DefaultHost("github.com")},
+			want: Location{
+				RawUrl:       "cli/cli",
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
+				RawPath:      "/cli/cli",
+				Path:         "",
+				Owner:        "cli",
+				Name:         "cli",
+				Branch:       "",
+				Url:          "https://github.com/cli/cli",
+				CloneUrl:     "https://github.com/cli/cli.git",
+				RemoteUrl:    "git@github.com:cli/cli.git",
+				QueryUrl:     "https://github.com/cli/cli",
+				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/.zip",
+				FileUrl:      "https://raw.githubusercontent.com/cli/cli//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Parse Clone Url Dotted Owner",
+			url:  "https://github.com/octo.github.io/site.git",
+			opts: nil,
+			want: Location{
+				RawUrl:       "https://github.com/octo.github.io/site.git",
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
+				RawPath:      "/octo.github.io/site",
+				Owner:        "octo.github.io",
+				Name:         "site",
+				Url:          "https://github.com/octo.github.io/site",
+				CloneUrl:     "https://github.com/octo.github.io/site.git",
+				RemoteUrl:    "git@github.com:octo.github.io/site.git",
+				QueryUrl:     "https://github.com/octo.github.io/site",
+				ArchiveUrl:   "https://github.com/octo.github.io/site/archive/refs/heads/.zip",
+				FileUrl:      "https://raw.githubusercontent.com/octo.github.io/site//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Parse Strict Unknown Host",
+			url:     "https://git.example.io/owner/repo",
+			opts:    []Option{Strict()},
+			want:    Location{},
+			wantErr: true,
+		},
+		{
+			name:    "Parse Not Valid Url",
+			url:     "https://github.com/cli",
+			opts:    nil,
+			want:    Location{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURL(tt.url, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseURL() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeepEqual got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseURL_Modes(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		opts    []Option
+		want    Location
+		wantErr bool
+	}{
+		{
+			name: "Parse Lenient Pasted Url",
+			url:  `"<https://www.github.com/cli/cli/tree/trunk/pkg>".`,
+			opts: []Option{Lenient()},
+			want: Location{
+				mode:         modeLenient,
+				RawUrl:       `"<https://www.github.com/cli/cli/tree/trunk/pkg>".`,
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
+				RawPath:      "/cli/cli/tree/trunk/pkg",
+				Path:         "pkg",
+				Owner:        "cli",
+				Name:         "cli",
+				Branch:       "trunk",
+				RefKind:      RefBranch,
+				Url:          "https://github.com/cli/cli/tree/trunk/pkg",
+				CloneUrl:     "https://github.com/cli/cli.git",
+				RemoteUrl:    "git@github.com:cli/cli.git",
+				QueryUrl:     "https://github.com/cli/cli/tree/trunk/pkg/",
+				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/trunk.zip",
+				FileUrl:      "https://raw.githubusercontent.com/cli/cli/trunk/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Parse Tree Folder With Filename",
+			url:  "https://github.com/cli/cli/tree/trunk/docs/tree",
+			opts: []Option{Filename("README.md")},
+			want: Location{
+				RawUrl:       "https://github.com/cli/cli/tree/trunk/docs/tree",
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
+				RawPath:      "/cli/cli/blob/trunk/docs/tree/README.md",
+				Path:         "docs/tree/README.md",
+				Owner:        "cli",
+				Name:         "cli",
+				Branch:       "trunk",
+				RefKind:      RefBranch,
+				Url:          "https://github.com/cli/cli/blob/trunk/docs/tree/README.md",
+				CloneUrl:     "https://github.com/cli/cli.git",
+				RemoteUrl:    "git@github.com:cli/cli.git",
+				QueryUrl:     "https://github.com/cli/cli/tree/trunk/docs/tree/",
+				ArchiveUrl:   "https://github.com/cli/cli/archive/refs/heads/trunk.zip",
+				FileUrl:      "https://raw.githubusercontent.com/cli/cli/trunk/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Parse Tree Owner With Filename",
+			url:  "https://github.com/tree/repo/tree/main/x",
+			opts: []Option{Filename("f")},
+			want: Location{
+				RawUrl:       "https://github.com/tree/repo/tree/main/x",
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "github.com",
+				Forge:        ForgeGitHub,
+				RawPath:      "/tree/repo/blob/main/x/f",
+				Path:         "x/f",
+				Owner:        "tree",
+				Name:         "repo",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				Url:          "https://github.com/tree/repo/blob/main/x/f",
+				CloneUrl:     "https://github.com/tree/repo.git",
+				RemoteUrl:    "git@github.com:tree/repo.git",
+				QueryUrl:     "https://github.com/tree/repo/tree/main/x/",
+				ArchiveUrl:   "https://github.com/tree/repo/archive/refs/heads/main.zip",
+				FileUrl:      "https://raw.githubusercontent.com/tree/repo/main/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Parse Strict Route Without Branch",
+			url:     "https://github.com/cli/cli/tree/",
+			opts:    []Option{Strict()},
+			want:    Location{},
+			wantErr: true,
+		},
+		{
+			name:    "Parse Strict Sourcehut Tree Without Branch",
+			url:     "https://git.sr.ht/~sircmpwn/scdoc/tree",
+			opts:    []Option{Strict()},
+			want:    Location{},
+			wantErr: true,
+		},
+		{
+			name:    "Parse Strict Gitiles Without Ref",
+			url:     "https://go.googlesource.com/go/+/",
+			opts:    []Option{Strict()},
+			want:    Location{},
+			wantErr: true,
+		},
+		{
+			name:    "Parse Strict Leftover Segments",
+			url:     "https://github.com/cli/cli/garbage",
+			opts:    []Option{Strict()},
+			want:    Location{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURL(tt.url, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseURL() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeepEqual got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepairRawUrl(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "Repair Quotes", url: `'https://github.com/cli/cli'`, want: "https://github.com/cli/cli"},
+		{name: "Repair Backticks", url: "`https://github.com/cli/cli`", want: "https://github.com/cli/cli"},
+		{name: "Repair Angle Brackets", url: "<https://github.com/cli/cli>", want: "https://github.com/cli/cli"},
+		{name: "Repair Markdown Link", url: "(https://github.com/cli/cli).", want: "https://github.com/cli/cli"},
+		{name: "Repair Trailing Punctuation", url: "https://github.com/cli/cli,", want: "https://github.com/cli/cli"},
+		{name: "Repair Missing Scheme", url: "  github.com/cli/cli  ", want: "https://github.com/cli/cli"},
+		{name: "Keep Scp-Style", url: "git@github.com:cli/cli.git", want: "git@github.com:cli/cli.git"},
+		{name: "Keep Owner Repo", url: "cli/cli", want: "cli/cli"},
+		{name: "Keep Parenthesis Path", url: "https://github.com/a/b/blob/main/f(1).go", want: "https://github.com/a/b/blob/main/f(1).go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repairRawUrl(tt.url); got != tt.want {
+				t.Errorf("repairRawUrl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocation_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		branch string
+		want   string
+	}{
+		{name: "Github Repository", url: "https://github.com/cli/cli.git", want: "https://github.com/cli/cli"},
+		{name: "Github File", url: "https://github.com/cli/cli/blob/trunk/go.mod#L3", want: "https://github.com/cli/cli/blob/trunk/go.mod"},
+		{name: "Github Folder", url: "https://github.com/cli/cli/tree/trunk/pkg", want: "https://github.com/cli/cli/tree/trunk/pkg/"},
+		{name: "Github Branch Hint", url: "https://github.com/cli/cli/tree/marwan/localcs/api", branch: "marwan/localcs", want: "https://github.com/cli/cli/tree/marwan/localcs/api/"},
+		{name: "Github Escaped Ref And Path", url: "https://github.com/cli/cli/tree/feat%23x/a%20b/c%3Fd", want: "https://github.com/cli/cli/tree/feat%23x/a%20b/c%3Fd/"},
+		{name: "Gitlab Subgroups Folder", url: "https://gitlab.com/gitlab-org/api/client-go/-/tree/main/examples", want: "https://gitlab.com/gitlab-org/api/client-go/tree/main/examples/"},
+		{name: "Bitbucket Folder", url: "https://bitbucket.org/tiagoharris/url-shortener/src/master/cmd/", want: "https://bitbucket.org/tiagoharris/url-shortener/src/master/cmd/"},
+		{name: "Bitbucket Escaped Folder", url: "https://bitbucket.org/o/r/src/b%20x/dir%20one/", want: "https://bitbucket.org/o/r/src/b%20x/dir%20one/"},
+		{name: "Gitea Tag Folder", url: "https://gitea.com/gitea/tea/src/tag/v0.9.2/cmd/", want: "https://gitea.com/gitea/tea/src/tag/v0.9.2/cmd/"},
+		{name: "Sourcehut Escaped File", url: "https://git.sr.ht/~o/r/blob/a%20b/x%23y.md", want: "https://git.sr.ht/~o/r/tree/a%20b/item/x%23y.md"},
+		{name: "Gitiles Folder", url: "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc", want: "https://go.googlesource.com/tools/+/refs/heads/master/gopls/doc/"},
+		{name: "Azure DevOps Escaped Query", url: "https://dev.azure.com/org/project/_git/repo?path=/src/a%20b&version=GBfeat%26x", want: "https://dev.azure.com/org/project/_git/repo?path=/src/a+b/&version=GBfeat%26x"},
+		{name: "Gitweb Escaped File", url: "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=blob;f=lisp/a%3Bb.el;hb=refs/heads/master", want: "https://git.savannah.gnu.org/gitweb/?p=emacs.git;a=blob;f=lisp/a%3Bb.el;hb=refs/heads/master"},
+		{name: "Cgit Folder", url: "https://git.kernel.org/pub/scm/git/git.git/tree/a%20b?h=x%26y", want: "https://git.kernel.org/pub/scm/git/git.git/tree/a%20b?h=x%26y"},
+		{name: "Unknown Host Src Folder", url: "https://git.example.com/o/r/src/main/docs/", want: "https://git.example.com/o/r/src/main/docs/"},
+		{name: "Gitlab Subgroups File", url: "https://gitlab.com/a/b/c/-/blob/main/docs/x.md", want: "https://gitlab.com/a/b/c/blob/main/docs/x.md"},
+		{name: "Gitlab Tree Owner Subgroups File", url: "https://gitlab.com/tree/b/c/-/blob/main/x.md", want: "https://gitlab.com/tree/b/c/blob/main/x.md"},
+		{name: "Cgit File Without Extension", url: "https://git.kernel.org/pub/scm/git/git.git/plain/Makefile?h=master", want: "https://git.kernel.org/pub/scm/git/git.git/plain/Makefile?h=master"},
+		{name: "Launchpad File Without Extension", url: "https://git.launchpad.net/cloud-init/plain/Makefile?h=main", want: "https://git.launchpad.net/cloud-init/plain/Makefile?h=main"},
+		{name: "Gitlab Subgroups Repository", url: "https://gitlab.com/a/b/c", want: "https://gitlab.com/a/b/c"},
+		{name: "Bitbucket Server Raw File Without Extension", url: "https://git.corp/users/jdoe/repos/dotfiles/raw/bin/setup?at=refs%2Fheads%2Fmain", want: "https://git.corp/users/jdoe/repos/dotfiles/raw/bin/setup?at=refs%2Fheads%2Fmain"},
+		{name: "Codecommit Fips Repository", url: "https://git-codecommit-fips.us-east-1.amazonaws.com/v1/repos/my-repo", want: "https://git-codecommit-fips.us-east-1.amazonaws.com/v1/repos/my-repo"},
+		{name: "Codecommit Fips Folder", url: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/main/--/src/?region=us-east-1&endpoint=fips", want: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/my-repo/browse/refs/heads/main/--/src/?region=us-east-1&endpoint=fips"},
+		{name: "Gitee Release Tag", url: "https://gitee.com/mindspore/mindspore/releases/tag/v1.0", want: "https://gitee.com/mindspore/mindspore/tree/refs/tags/v1.0/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := ParseURL(tt.url, BranchHint(tt.branch))
+			if err != nil {
+				t.Fatalf("ParseURL() error = %v", err)
+			}
+			if got := l.String(); got != tt.want {
+				t.Errorf("Location.String() = %v, want %v", got, tt.want)
+			}
+			text, err := l.MarshalText()
+			if err != nil || string(text) != tt.want {
+				t.Errorf("Location.MarshalText() = %s, %v, want %v", text, err, tt.want)
+			}
+
+			// parse of canonical url is the same location
+			got := Location{}
+			if tt.branch == "" {
+				if err := got.UnmarshalText(text); err != nil {
+					t.Fatalf("Location.UnmarshalText() error = %v", err)
+				}
+			} else if got, err = ParseURL(string(text), BranchHint(tt.branch)); err != nil {
+				t.Fatalf("ParseURL() error = %v", err)
+			}
+			want := l
+			want.RawUrl = tt.want
+			if l.RawUrl != tt.url {
+				t.Errorf("ParseURL() RawUrl = %v", l.RawUrl)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("DeepEqual got = %#v, want %#v", got, want)
+			}
+		})
+	}
+
+	if _, err := (Location{}).MarshalText(); err == nil {
+		t.Errorf("Location.MarshalText() error = nil, want error")
+	}
+	if got := (Location{}).String(); got != "" {
+		t.Errorf("Location.String() = %v, want empty", got)
+	}
+}
+
+func TestLocation_StringRoundTrip(t *testing.T) {
+	for _, seed := range tableTestSeeds(t) {
+		l, err := ParseURL(seed.url, BranchHint(seed.branch), Filename(seed.filename))
+		if err != nil {
+			continue
+		}
+
+		// canonical url of every parsed table test url parses back to the same location
+		text := l.String()
+		got, err := ParseURL(text, BranchHint(l.Branch))
+		if err != nil {
+			t.Errorf("ParseURL(%q) of %q error = %v", text, seed.url, err)
+			continue
+		}
+		if !got.Equal(l) || got.Key() != l.Key() {
+			t.Errorf("ParseURL(%q) of %q = %#v, want %#v", text, seed.url, got, l)
+		}
+	}
+}
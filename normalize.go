@@ -0,0 +1,115 @@
+package gitrepository
+
+import (
+	"net/url"
+	"strings"
+)
+
+// hostname prefixes of web and mobile sites, they serve the same repositories
+var hostnamePrefixes = []string{"www.", "m."}
+
+// providers with case insensitive owners and names fold them to the case of their urls
+// raw path of repository path providers folds too, positional raw paths rebuild
+type caseFolder interface {
+	foldCase(l *Location)
+}
+
+// normalized location of the same repository, ref and path:
+// https scheme and protocol, hostname without www. and m., lowercase hostname,
+// provider case of owner and name, no .git suffix and trailing slashes
+// urls regenerate, raw url stays
+/*
+http://www.github.com/CLI/cli.git/ -> https://github.com/cli/cli
+git@github.com:cli/cli             -> https://github.com/cli/cli
+*/
+func (l Location) Normalize() Location {
+	if l.Hostname == "" {
+		return l
+	}
+
+	n := l.normalizeFields()
+	n.rebuild()
+
+	return n
+}
+
+// normalize fields of Normalize, urls and positional raw paths stay
+func (l Location) normalizeFields() Location {
+	n := l
+	n.Protocol = "https"
+	if n.Scheme == "http" {
+		n.Scheme = "https"
+	}
+	n.Hostname = normalizeHostname(l.Hostname)
+	n.Name = trimGitSuffix(n.Name)
+	n.Path = strings.Trim(n.Path, "/")
+
+	// hostname change can change provider: www.github.com is an unknown host
+	// locations without forge (json of other processes) find the provider of their host, unknown hosts keep parsed fields
+	if n.Hostname != strings.ToLower(l.Hostname) || (n.Forge == "" && n.hasKnownProvider()) {
+		if parsed, err := ParseURL(n.String(), BranchHint(n.Branch)); err == nil {
+			parsed.logger, parsed.mode = l.logger, l.mode
+			parsed.RawUrl = l.RawUrl
+			n = parsed
+		}
+	}
+
+	if folder, ok := n.getProvider().(caseFolder); ok {
+		folder.foldCase(&n)
+	}
+
+	return n
+}
+
+// provider of browse url host is not the generic provider
+func (l *Location) hasKnownProvider() bool {
+	u, err := url.Parse(l.String())
+	if err != nil {
+		return false
+	}
+
+	return findProvider(u).Forge() != ""
+}
+
+// same repository, ref and path after Normalize
+// scheme, protocol, hostname prefix, owner and name case, .git suffix and trailing slashes are presentation
+func (l Location) Equal(other Location) bool {
+	a, b := l.normalizeFields(), other.normalizeFields()
+
+	return a.isSameRepository(&b) && a.Branch == b.Branch && a.RefKind == b.RefKind && a.Path == b.Path && a.IsFile == b.IsFile
+}
+
+// same repository after Normalize, refs and paths ignored
+func (l Location) SameRepository(other Location) bool {
+	a, b := l.normalizeFields(), other.normalizeFields()
+
+	return a.isSameRepository(&b)
+}
+
+// compare repository fields of normalized locations, unparsed locations are not the same
+func (l *Location) isSameRepository(other *Location) bool {
+	if l.Hostname == "" || other.Hostname == "" {
+		return false
+	}
+
+	return l.Hostname == other.Hostname && l.Forge == other.Forge && l.Region == other.Region &&
+		l.RepoType == other.RepoType && l.Owner == other.Owner && l.Name == other.Name
+}
+
+// lowercase hostname without www. and m. prefixes
+// WWW.GitHub.com -> github.com, m.github.com -> github.com, m.com stays
+func normalizeHostname(hostname string) string {
+	hostname = strings.ToLower(hostname)
+	for _, prefix := range hostnamePrefixes {
+		if rest, ok := strings.CutPrefix(hostname, prefix); ok && strings.Contains(rest, ".") {
+			return rest
+		}
+	}
+
+	return hostname
+}
+
+// lowercase owner and name, hosts redirect other cases
+func (l *Location) lowerOwnerName() {
+	l.Owner, l.Name = strings.ToLower(l.Owner), strings.ToLower(l.Name)
+}
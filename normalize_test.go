@@ -0,0 +1,173 @@
+package gitrepository
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLocation_Normalize(t *testing.T) {
+	tests := []struct {
+		name string
+		urls []string // all normalize to parse of first url
+	}{
+		{
+			name: "Github Repository",
+			urls: []string{"https://github.com/cli/cli", "http://www.github.com/CLI/cli.git/", "git@github.com:cli/cli", "https://m.github.com/Cli/Cli", "ssh://git@github.com/cli/CLI.git", "https://github.com/cli/cli.GIT"},
+		},
+		{
+			name: "Github Folder",
+			urls: []string{"https://github.com/cli/cli/tree/trunk/pkg", "http://WWW.GitHub.com/CLI/CLI/tree/trunk/pkg/"},
+		},
+		{
+			name: "Gitlab Subgroups",
+			urls: []string{"https://gitlab.com/gitlab-org/api/client-go/-/tree/main/examples", "http://GitLab.com/GitLab-Org/API/client-go/-/tree/main/examples/"},
+		},
+		{
+			name: "Bitbucket Folder",
+			urls: []string{"https://bitbucket.org/tiagoharris/url-shortener/src/master/cmd/", "http://bitbucket.org/TiagoHarris/URL-Shortener/src/master/cmd/"},
+		},
+		{
+			name: "Forgejo Tag",
+			urls: []string{"https://codeberg.org/forgejo/forgejo/src/tag/v1.21.0/docs/", "http://codeberg.org/ForgeJo/Forgejo/src/tag/v1.21.0/docs/"},
+		},
+		{
+			name: "Gitee Repository",
+			urls: []string{"https://gitee.com/mindspore/mindspore", "git@gitee.com:MindSpore/MindSpore.git"},
+		},
+		{
+			name: "Azure DevOps Repository",
+			urls: []string{"https://dev.azure.com/org/project/_git/repo", "git@ssh.dev.azure.com:v3/Org/Project/Repo"},
+		},
+		{
+			name: "Bitbucket Server Repository",
+			urls: []string{"https://git.corp/projects/KEY/repos/slug", "ssh://git@git.corp:7999/key/Slug.git"},
+		},
+	}
+	restoreRegistry(t)
+	RegisterForge("git.corp", ForgeBitbucketServer)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := ParseURL(tt.urls[0])
+			if err != nil {
+				t.Fatalf("ParseURL(%q) error = %v", tt.urls[0], err)
+			}
+			for _, raw := range tt.urls {
+				l, err := ParseURL(raw)
+				if err != nil {
+					t.Fatalf("ParseURL(%q) error = %v", raw, err)
+				}
+
+				got := l.Normalize()
+				want.RawUrl = raw
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Location.Normalize(%q) = %#v, want %#v", raw, got, want)
+				}
+				if !reflect.DeepEqual(got.Normalize(), got) {
+					t.Errorf("Location.Normalize(%q) is not idempotent", raw)
+				}
+			}
+		})
+	}
+}
+
+func TestLocation_NormalizeWithoutForge(t *testing.T) {
+	// json of other processes, no forge of known host
+	l := Location{}
+	data := `{"version":1,"protocol":"https","scheme":"https","hostname":"github.com","raw_path":"/CLI/cli","owner":"CLI","name":"cli"}`
+	if err := json.Unmarshal([]byte(data), &l); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	want, err := ParseURL("https://github.com/cli/cli")
+	if err != nil {
+		t.Fatalf("ParseURL() error = %v", err)
+	}
+	want.RawUrl = ""
+	if got := l.Normalize(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Location.Normalize() = %#v, want %#v", got, want)
+	}
+	if got := l.Key(); got != want.Key() {
+		t.Errorf("Location.Key() = %#v, want %#v", got, want.Key())
+	}
+}
+
+func TestLocation_Equal(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		other    string
+		wantEq   bool
+		wantSame bool
+	}{
+		{name: "Same Repository Other Forms", url: "http://www.github.com/CLI/cli.git/", other: "git@github.com:cli/cli", wantEq: true, wantSame: true},
+		{name: "Same Folder Other Case", url: "https://github.com/cli/cli/tree/trunk/pkg", other: "https://github.com/CLI/CLI/tree/trunk/pkg/", wantEq: true, wantSame: true},
+		{name: "Branch Case Matters", url: "https://github.com/cli/cli/tree/trunk/pkg", other: "https://github.com/cli/cli/tree/Trunk/pkg", wantSame: true},
+		{name: "Path Case Matters", url: "https://github.com/cli/cli/tree/trunk/pkg", other: "https://github.com/cli/cli/tree/trunk/PKG", wantSame: true},
+		{name: "Other Branch", url: "https://github.com/cli/cli/tree/trunk", other: "https://github.com/cli/cli", wantSame: true},
+		{name: "Other Host", url: "https://github.com/a/b", other: "https://gitlab.com/a/b"},
+		{name: "Unknown Host Case Matters", url: "https://git.example.com/Team/app", other: "https://git.example.com/team/app"},
+		{name: "Gitiles Case Matters", url: "https://go.googlesource.com/Tools", other: "https://go.googlesource.com/tools"},
+		{name: "Hugging Face Repo Type", url: "https://huggingface.co/openai/gsm8k", other: "https://huggingface.co/datasets/openai/gsm8k"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := ParseURL(tt.url)
+			if err != nil {
+				t.Fatalf("ParseURL(%q) error = %v", tt.url, err)
+			}
+			other, err := ParseURL(tt.other)
+			if err != nil {
+				t.Fatalf("ParseURL(%q) error = %v", tt.other, err)
+			}
+
+			if got := l.Equal(other); got != tt.wantEq {
+				t.Errorf("Location.Equal() = %v, want %v", got, tt.wantEq)
+			}
+			if got := other.Equal(l); got != tt.wantEq {
+				t.Errorf("Location.Equal() reverse = %v, want %v", got, tt.wantEq)
+			}
+			if got := l.SameRepository(other); got != tt.wantSame {
+				t.Errorf("Location.SameRepository() = %v, want %v", got, tt.wantSame)
+			}
+
+			r, o := NewGitRepository("", "", tt.url, ""), NewGitRepository("", "", tt.other, "")
+			if err := r.Parse("", DirectionNone, ""); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+			if err := o.Parse("", DirectionNone, ""); err != nil {
+				t.Fatalf("GitRepository.Parse() error = %v", err)
+			}
+			if got := r.Equal(o); got != tt.wantEq {
+				t.Errorf("GitRepository.Equal() = %v, want %v", got, tt.wantEq)
+			}
+			if got := r.SameRepository(o); got != tt.wantSame {
+				t.Errorf("GitRepository.SameRepository() = %v, want %v", got, tt.wantSame)
+			}
+		})
+	}
+
+	if (Location{}).SameRepository(Location{}) {
+		t.Errorf("Location.SameRepository() of unparsed locations = true")
+	}
+}
+
+func TestGitRepository_Normalize(t *testing.T) {
+	r := NewGitRepository("/tmp", "ssid", "http://www.github.com/CLI/cli.git/", "")
+	if err := r.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+
+	want := NewGitRepository("/tmp", "ssid", "https://github.com/cli/cli", "")
+	if err := want.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+	want.RawUrl = r.RawUrl
+
+	if got := r.Normalize(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GitRepository.Normalize() = %#v, want %#v", got, want)
+	}
+	if r.Owner != "CLI" {
+		t.Errorf("GitRepository.Normalize() changed repository = %#v", r)
+	}
+}
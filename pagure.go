@@ -0,0 +1,177 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// pagure route keywords after repository path
+var pagureRoutes = map[string]bool{
+	"blob": true, "raw": true, "tree": true, "archive": true, "commits": true,
+	"c": true, "branches": true, "tags": true, "releases": true, "issues": true,
+	"pull-requests": true, "history": true, "blame": true,
+}
+
+// pagure provider, pagure.io and fedora package sources
+type pagureProvider struct {
+	GenericProvider
+}
+
+func (pagureProvider) Forge() string {
+	return ForgePagure
+}
+
+func (pagureProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "pagure.io", "src.fedoraproject.org")
+}
+
+func (pagureProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parsePagureRoute(u, filename)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME] - pagure owner is optional
+func (pagureProvider) BaseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + l.RawPath
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/blob/[BRANCH]/f/[PATH]
+func (pagureProvider) BrowseUrl(l *Location) string {
+	return l.getPagureBlobUrl(l.Path)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME].git
+// https://[HOSTNAME]/forks/[USER]/[NAME].git
+func (pagureProvider) CloneUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + l.getPagureClonePath()
+}
+
+// ssh://git@[HOSTNAME]/[OWNER]/[NAME].git
+func (pagureProvider) RemoteUrl(l *Location) string {
+	return "ssh://git@" + l.Hostname + l.getPagureClonePath()
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/archive/[BRANCH]/[NAME]-[BRANCH].tar.gz
+func (pagureProvider) ArchiveUrl(l *Location) string {
+	return l.getBaseUrl() + "/archive/" + l.Branch + "/" + l.Name + "-" + l.Branch + ".tar.gz"
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/raw/[BRANCH]/f/[PATH]
+// https://pagure.io/pagure/raw/master/f/README.rst
+func (pagureProvider) FileUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/raw/" + l.Branch + "/f/" + path
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/blob/[BRANCH]/f/[PATH]
+func (pagureProvider) QueryUrl(l *Location, path string) string {
+	return l.getPagureBlobUrl(path)
+}
+
+// parse pagure routes, repositories have optional namespaces and fork prefixes
+/*
+https://pagure.io/<repo>
+https://src.fedoraproject.org/<namespace>/<repo> -> owner: <namespace>
+https://pagure.io/fork/<user>/[<namespace>/]<repo> -> owner: fork/<user>[/<namespace>]
+https://pagure.io/<repo>/blob/<branch>/f/<path> -> f splits branch and path
+https://pagure.io/<repo>/raw/<branch>/f/<path> -> single file
+https://pagure.io/<repo>/tree/<branch> -> root folder
+https://pagure.io/<repo>/archive/<branch>/<repo>-<branch>.tar.gz
+https://pagure.io/forks/<user>/<repo>.git -> fork clone url
+ssh://git@pagure.io/<repo>.git
+*/
+func (l *Location) parsePagureRoute(u *url.URL, filename string) error {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	// fork/<user>/<repo> web, forks/<user>/<repo>.git clone
+	fork := []string{}
+	if (segments[0] == "fork" || segments[0] == "forks") && len(segments) > 2 {
+		fork, segments = []string{"fork", segments[1]}, segments[2:]
+	}
+
+	// <namespace>/<repo>/<route>, route keywords after first segment only
+	index := len(segments)
+	for i := 1; i < len(segments); i++ {
+		if pagureRoutes[segments[i]] {
+			index = i
+			break
+		}
+	}
+	if index > 2 {
+		return newParseError(ErrUnknownRoute, segments[2])
+	}
+
+	repository, route, rest := segments[:index], "", []string{}
+	if index < len(segments) {
+		route, rest = segments[index], segments[index+1:]
+	}
+
+	l.Name = trimGitSuffix(repository[len(repository)-1])
+	if l.Name == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+	l.Owner = strings.Join(append(fork, repository[:len(repository)-1]...), "/")
+	l.RawPath = "/" + l.Name
+	if l.Owner != "" {
+		l.RawPath = "/" + l.Owner + "/" + l.Name
+	}
+
+	l.traceRoute(route)
+	switch route {
+	case "":
+	case "blob", "raw", "tree":
+		// <branch>/f/<path>, branch names with slashes end before f
+		ref := rest
+		for i, segment := range rest {
+			if segment == "f" {
+				ref, l.Path = rest[:i], strings.Join(rest[i+1:], "/")
+				break
+			}
+		}
+		if len(ref) == 0 {
+			return newParseError(ErrInvalidRef, "")
+		}
+		l.Branch, l.RefKind = splitRef(strings.Join(ref, "/"))
+	case "archive":
+		// <branch>/<repo>-<branch>.tar.gz
+		if len(rest) < 2 {
+			return newParseError(ErrInvalidRef, strings.Join(rest, "/"))
+		}
+		l.Branch, l.RefKind = splitRef(strings.Join(rest[:len(rest)-1], "/"))
+	default:
+		return newParseError(ErrUnknownRoute, route)
+	}
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+
+	// raw route only serves files, blob urls serve folders too
+	l.IsFile = filename != "" || (route == "raw" && l.Path != "") || (l.Path != "" && !strings.HasSuffix(u.Path, "/") && filepath.Ext(l.Path) != "")
+
+	return nil
+}
+
+// generate pagure clone path, forks clone from forks/
+// /[OWNER]/[NAME].git, /forks/[USER]/[NAME].git
+func (l *Location) getPagureClonePath() string {
+	if strings.HasPrefix(l.RawPath, "/fork/") {
+		return "/forks/" + strings.TrimPrefix(l.RawPath, "/fork/") + ".git"
+	}
+
+	return l.RawPath + ".git"
+}
+
+// generate pagure web url
+// https://[HOSTNAME]/[OWNER]/[NAME]/blob/[BRANCH]/f/[PATH]
+func (l *Location) getPagureBlobUrl(path string) string {
+	if l.Branch == "" && path == "" {
+		return l.getBaseUrl()
+	}
+
+	branch := escapePath(l.Branch)
+	if branch == "" {
+		branch = "HEAD"
+	}
+	if path == "" {
+		return l.getBaseUrl() + "/tree/" + branch
+	}
+
+	return l.getBaseUrl() + "/blob/" + branch + "/f/" + escapePath(path)
+}
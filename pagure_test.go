@@ -0,0 +1,513 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_PagureParse(t *testing.T) {
+	restoreRegistry(t)
+	RegisterForge("pagure.example.com", ForgePagure)
+
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Pagure Repository",
+			url:    "https://pagure.io/pagure",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/pagure",
+				RawUrl:       "https://pagure.io/pagure",
+				CloneUrl:     "https://pagure.io/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/pagure.git",
+				QueryUrl:     "https://pagure.io/pagure",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Name: "pagure", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/pagure",
+				Path:         "",
+				Owner:        "",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://pagure.io/pagure/archive//pagure-.tar.gz",
+				FileUrl:      "https://pagure.io/pagure/raw//f/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Single File",
+			url:    "https://pagure.io/pagure/blob/master/f/pagure/lib/query.py",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/pagure/blob/master/f/pagure/lib/query.py",
+				RawUrl:       "https://pagure.io/pagure/blob/master/f/pagure/lib/query.py",
+				CloneUrl:     "https://pagure.io/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/pagure.git",
+				QueryUrl:     "https://pagure.io/pagure/blob/master/f/pagure/lib",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Name: "pagure", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/pagure",
+				Path:         "pagure/lib/query.py",
+				Owner:        "",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://pagure.io/pagure/archive/master/pagure-master.tar.gz",
+				FileUrl:      "https://pagure.io/pagure/raw/master/f/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Folder",
+			url:    "https://pagure.io/pagure/blob/master/f/pagure/lib/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/pagure/blob/master/f/pagure/lib",
+				RawUrl:       "https://pagure.io/pagure/blob/master/f/pagure/lib/",
+				CloneUrl:     "https://pagure.io/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/pagure.git",
+				QueryUrl:     "https://pagure.io/pagure/blob/master/f/pagure/lib",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Name: "pagure", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/pagure",
+				Path:         "pagure/lib",
+				Owner:        "",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://pagure.io/pagure/archive/master/pagure-master.tar.gz",
+				FileUrl:      "https://pagure.io/pagure/raw/master/f/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Raw Single File",
+			url:    "https://pagure.io/pagure/raw/5.13.3/f/README.rst",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/pagure/blob/5.13.3/f/README.rst",
+				RawUrl:       "https://pagure.io/pagure/raw/5.13.3/f/README.rst",
+				CloneUrl:     "https://pagure.io/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/pagure.git",
+				QueryUrl:     "https://pagure.io/pagure/tree/5.13.3",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Name: "pagure", RefKind: RefBranch, Ref: "5.13.3"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/pagure",
+				Path:         "README.rst",
+				Owner:        "",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "5.13.3",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://pagure.io/pagure/archive/5.13.3/pagure-5.13.3.tar.gz",
+				FileUrl:      "https://pagure.io/pagure/raw/5.13.3/f/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Slashes Branch Folder",
+			url:    "https://pagure.io/pagure/blob/release/5.x/f/doc/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/pagure/blob/release/5.x/f/doc",
+				RawUrl:       "https://pagure.io/pagure/blob/release/5.x/f/doc/",
+				CloneUrl:     "https://pagure.io/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/pagure.git",
+				QueryUrl:     "https://pagure.io/pagure/blob/release/5.x/f/doc",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Name: "pagure", RefKind: RefBranch, Ref: "release/5.x"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/pagure",
+				Path:         "doc",
+				Owner:        "",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "release/5.x",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://pagure.io/pagure/archive/release/5.x/pagure-release/5.x.tar.gz",
+				FileUrl:      "https://pagure.io/pagure/raw/release/5.x/f/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Tree Url",
+			url:    "https://pagure.io/pagure/tree/master",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/pagure/tree/master",
+				RawUrl:       "https://pagure.io/pagure/tree/master",
+				CloneUrl:     "https://pagure.io/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/pagure.git",
+				QueryUrl:     "https://pagure.io/pagure/tree/master",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Name: "pagure", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/pagure",
+				Path:         "",
+				Owner:        "",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://pagure.io/pagure/archive/master/pagure-master.tar.gz",
+				FileUrl:      "https://pagure.io/pagure/raw/master/f/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Namespace Single File",
+			url:    "https://src.fedoraproject.org/rpms/bash/blob/rawhide/f/bash.spec",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://src.fedoraproject.org/rpms/bash/blob/rawhide/f/bash.spec",
+				RawUrl:       "https://src.fedoraproject.org/rpms/bash/blob/rawhide/f/bash.spec",
+				CloneUrl:     "https://src.fedoraproject.org/rpms/bash.git",
+				RemoteUrl:    "ssh://git@src.fedoraproject.org/rpms/bash.git",
+				QueryUrl:     "https://src.fedoraproject.org/rpms/bash/tree/rawhide",
+				DirPath:      keyDirPath(Key{Hostname: "src.fedoraproject.org", Namespace: "rpms", Name: "bash", RefKind: RefBranch, Ref: "rawhide"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "src.fedoraproject.org",
+				Forge:        ForgePagure,
+				RawPath:      "/rpms/bash",
+				Path:         "bash.spec",
+				Owner:        "rpms",
+				Name:         "bash",
+				DummyBranch:  "gitd-branch",
+				Branch:       "rawhide",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://src.fedoraproject.org/rpms/bash/archive/rawhide/bash-rawhide.tar.gz",
+				FileUrl:      "https://src.fedoraproject.org/rpms/bash/raw/rawhide/f/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Fork Repository",
+			url:    "https://pagure.io/fork/jdoe/pagure",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/fork/jdoe/pagure",
+				RawUrl:       "https://pagure.io/fork/jdoe/pagure",
+				CloneUrl:     "https://pagure.io/forks/jdoe/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/forks/jdoe/pagure.git",
+				QueryUrl:     "https://pagure.io/fork/jdoe/pagure",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Namespace: "fork/jdoe", Name: "pagure", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/fork/jdoe/pagure",
+				Path:         "",
+				Owner:        "fork/jdoe",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://pagure.io/fork/jdoe/pagure/archive//pagure-.tar.gz",
+				FileUrl:      "https://pagure.io/fork/jdoe/pagure/raw//f/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Fork Namespace Raw Single File",
+			url:    "https://src.fedoraproject.org/fork/jdoe/rpms/bash/raw/rawhide/f/bash.spec",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://src.fedoraproject.org/fork/jdoe/rpms/bash/blob/rawhide/f/bash.spec",
+				RawUrl:       "https://src.fedoraproject.org/fork/jdoe/rpms/bash/raw/rawhide/f/bash.spec",
+				CloneUrl:     "https://src.fedoraproject.org/forks/jdoe/rpms/bash.git",
+				RemoteUrl:    "ssh://git@src.fedoraproject.org/forks/jdoe/rpms/bash.git",
+				QueryUrl:     "https://src.fedoraproject.org/fork/jdoe/rpms/bash/tree/rawhide",
+				DirPath:      keyDirPath(Key{Hostname: "src.fedoraproject.org", Namespace: "fork/jdoe/rpms", Name: "bash", RefKind: RefBranch, Ref: "rawhide"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "src.fedoraproject.org",
+				Forge:        ForgePagure,
+				RawPath:      "/fork/jdoe/rpms/bash",
+				Path:         "bash.spec",
+				Owner:        "fork/jdoe/rpms",
+				Name:         "bash",
+				DummyBranch:  "gitd-branch",
+				Branch:       "rawhide",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://src.fedoraproject.org/fork/jdoe/rpms/bash/archive/rawhide/bash-rawhide.tar.gz",
+				FileUrl:      "https://src.fedoraproject.org/fork/jdoe/rpms/bash/raw/rawhide/f/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Fork Clone Url",
+			url:    "https://pagure.io/forks/jdoe/pagure.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/fork/jdoe/pagure",
+				RawUrl:       "https://pagure.io/forks/jdoe/pagure.git",
+				CloneUrl:     "https://pagure.io/forks/jdoe/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/forks/jdoe/pagure.git",
+				QueryUrl:     "https://pagure.io/fork/jdoe/pagure",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Namespace: "fork/jdoe", Name: "pagure", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/fork/jdoe/pagure",
+				Path:         "",
+				Owner:        "fork/jdoe",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://pagure.io/fork/jdoe/pagure/archive//pagure-.tar.gz",
+				FileUrl:      "https://pagure.io/fork/jdoe/pagure/raw//f/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Ssh Remote Url",
+			url:    "ssh://git@pagure.io/pagure.git",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/pagure",
+				RawUrl:       "ssh://git@pagure.io/pagure.git",
+				CloneUrl:     "https://pagure.io/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/pagure.git",
+				QueryUrl:     "https://pagure.io/pagure",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Name: "pagure", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/pagure",
+				Path:         "",
+				Owner:        "",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://pagure.io/pagure/archive//pagure-.tar.gz",
+				FileUrl:      "https://pagure.io/pagure/raw//f/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Archive Url",
+			url:    "https://pagure.io/pagure/archive/5.13.3/pagure-5.13.3.tar.gz",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.io/pagure/tree/5.13.3",
+				RawUrl:       "https://pagure.io/pagure/archive/5.13.3/pagure-5.13.3.tar.gz",
+				CloneUrl:     "https://pagure.io/pagure.git",
+				RemoteUrl:    "ssh://git@pagure.io/pagure.git",
+				QueryUrl:     "https://pagure.io/pagure/tree/5.13.3",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.io", Name: "pagure", RefKind: RefBranch, Ref: "5.13.3"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.io",
+				Forge:        ForgePagure,
+				RawPath:      "/pagure",
+				Path:         "",
+				Owner:        "",
+				Name:         "pagure",
+				DummyBranch:  "gitd-branch",
+				Branch:       "5.13.3",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://pagure.io/pagure/archive/5.13.3/pagure-5.13.3.tar.gz",
+				FileUrl:      "https://pagure.io/pagure/raw/5.13.3/f/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Registered Host",
+			url:    "https://pagure.example.com/infra/ansible/blob/main/f/roles/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://pagure.example.com/infra/ansible/blob/main/f/roles",
+				RawUrl:       "https://pagure.example.com/infra/ansible/blob/main/f/roles/",
+				CloneUrl:     "https://pagure.example.com/infra/ansible.git",
+				RemoteUrl:    "ssh://git@pagure.example.com/infra/ansible.git",
+				QueryUrl:     "https://pagure.example.com/infra/ansible/blob/main/f/roles",
+				DirPath:      keyDirPath(Key{Hostname: "pagure.example.com", Namespace: "infra", Name: "ansible", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "pagure.example.com",
+				Forge:        ForgePagure,
+				RawPath:      "/infra/ansible",
+				Path:         "roles",
+				Owner:        "infra",
+				Name:         "ansible",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://pagure.example.com/infra/ansible/archive/main/ansible-main.tar.gz",
+				FileUrl:      "https://pagure.example.com/infra/ansible/raw/main/f/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Pagure Unknown Route",
+			url:    "https://pagure.io/pagure/issues",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://pagure.io/pagure/issues",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "pagure.io",
+				Forge:       ForgePagure,
+				RawPath:     "/pagure",
+				Path:        "",
+				Owner:       "",
+				Name:        "pagure",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Parse Pagure Blob Without Branch",
+			url:    "https://pagure.io/pagure/blob/",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://pagure.io/pagure/blob/",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "pagure.io",
+				Forge:       ForgePagure,
+				RawPath:     "/pagure",
+				Path:        "",
+				Owner:       "",
+				Name:        "pagure",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// git hosting software url rules
+// providers match urls, parse url routes into location and build urls from location
+type Provider interface {
+	// forge name: github|gitlab|...
+	Forge() string
+	// match url by hostname or routes, registered hostnames match without it
+	Match(u *url.URL) bool
+	// parse url routes: owner, name, branch, path
+	// user set branch name lives in location before parse
+	ParseRoute(l *Location, u *url.URL, filename string) error
+
+	// repository web url without branch and path
+	BaseUrl(l *Location) string
+	// clean web url of branch and path
+	BrowseUrl(l *Location) string
+	CloneUrl(l *Location) string
+	// remote url for git git@github.com:username/repo.git
+	RemoteUrl(l *Location) string
+	// download branch package, empty if not supported
+	ArchiveUrl(l *Location) string
+	// download single file of path, empty if not supported
+	FileUrl(l *Location, path string) string
+	// folder web url of path for search bar, branch is not empty
+	QueryUrl(l *Location, path string) string
+}
+
+// generic provider: owner/name/tree|blob|src/branch/path positional routes
+// unknown hosts use it, embed it for the same routes
+type GenericProvider struct{}
+
+func (GenericProvider) Forge() string {
+	return ""
+}
+
+func (GenericProvider) Match(u *url.URL) bool {
+	return false
+}
+
+func (GenericProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parsePositionalRoute(u, filename, false)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]
+func (GenericProvider) BaseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + "/" + l.Owner + "/" + l.Name
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME]/[RAWPATH]
+func (GenericProvider) BrowseUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + escapePath(l.RawPath)
+}
+
+// https://[HOSTNAME]/[OWNER]/[NAME].git
+func (GenericProvider) CloneUrl(l *Location) string {
+	return l.Scheme + "://" + l.Hostname + "/" + l.Owner + "/" + l.Name + ".git"
+}
+
+// git@[HOSTNAME]:[OWNER]/[NAME].git
+func (GenericProvider) RemoteUrl(l *Location) string {
+	return "git@" + l.Hostname + ":" + l.Owner + "/" + l.Name + ".git"
+}
+
+// Not supported: unknown hosts
+func (GenericProvider) ArchiveUrl(l *Location) string {
+	return ""
+}
+
+// Not supported: unknown hosts
+func (GenericProvider) FileUrl(l *Location, path string) string {
+	return ""
+}
+
+// Not supported: unknown hosts
+func (GenericProvider) QueryUrl(l *Location, path string) string {
+	return l.getBaseUrl()
+}
+
+// parse owner/name/tree|blob/branch/path positional routes
+// subgroups: owner has slashes, tree or blob segment splits owner/name and branch
+func (l *Location) parsePositionalRoute(u *url.URL, filename string, subgroups bool) error {
+	// owner and name of url path, filepath.Join collapses empty segments: //repo has no owner
+	if err := requireOwnerName(strings.Split(strings.TrimPrefix(u.Path, "/"), "/")); err != nil {
+		return err
+	}
+
+	// url path has no querystring, fragments
+	l.RawPath = filepath.Join(u.Path, filename)
+
+	// little fix - file recheck
+	if !l.IsFile {
+		l.IsFile = !strings.HasSuffix(l.RawUrl, "/") // only useful for bitbucket.org url
+		if l.isExplaining() {
+			l.explain(ExplainStep{Rule: "trailing slash", Decision: strconv.FormatBool(l.IsFile), Evidence: "file urls have no trailing slash", Fields: []string{"is_file"}})
+		}
+	}
+
+	l.RawPath = strings.TrimSuffix(l.RawPath, "/") // remove last slashes
+
+	// split once: n[1] = owner, n[2] = repo, n[3] = tree|blob, n[4] = branch, n[5:] = ../../../...
+	n := strings.Split(l.RawPath, "/")
+	if len(n) < 3 {
+		// dot segments of path: /owner/repo/..
+		if err := requireOwnerName(strings.Split(strings.Trim(l.RawPath, "/"), "/")); err != nil {
+			return err
+		}
+		return newParseError(ErrMissingRepo, "")
+	}
+
+	// route segment index, owner and name before it
+	route := 3
+	l.Owner, l.Name = n[1], n[2]
+	if subgroups {
+		// first tree or blob segment after owner and name, files of subgroups too
+		found := false
+		for i := route; i < len(n); i++ {
+			if n[i] == "tree" || n[i] == "blob" {
+				if i >= 4 {
+					// detect looonnnngggg folder urls
+					route = i
+					l.Owner, l.Name = strings.Join(n[1:i-1], "/"), n[i-1]
+				}
+				found = true
+				break
+			}
+		}
+
+		// route-less deep paths are subgroup repositories: /a/b/c, git@gitlab.com:a/b/c.git
+		// routes of gitlab urls follow /-/, filenames are not repository segments
+		if !found && len(n) > 3 && filename == "" && !strings.Contains(l.RawUrl, "/-/") {
+			route = len(n)
+			l.Owner, l.Name = strings.Join(n[1:route-1], "/"), n[route-1]
+		}
+	}
+
+	// .git suffix of name segment only, owners like user.github.io stay
+	if name := trimGitSuffix(l.Name); name != l.Name {
+		l.Name, n[route-1] = name, name
+		l.RawPath = strings.Join(n, "/")
+	}
+	if l.Name == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+	if l.isExplaining() {
+		step := ExplainStep{Rule: "owner name split", Decision: "owner " + l.Owner + ", name " + l.Name, Evidence: "path segments 1 and 2", Fields: []string{"owner", "name"}}
+		if route != 3 {
+			step.Rule, step.Evidence = "subgroups", "route-less path, owner has subgroups"
+			if route < len(n) {
+				step.Evidence = n[route] + " segment at " + strconv.Itoa(route) + ", owner has subgroups"
+			}
+			step.Rejected = []string{"owner " + n[1] + ", name " + n[2]}
+		}
+		l.explain(step)
+	}
+
+	if len(n) <= route {
+		l.IsFile = false
+		if l.isExplaining() {
+			l.explain(ExplainStep{Rule: "repository root", Decision: "folder", Evidence: "no route segment after owner and name", Fields: []string{"is_file", "branch", "path"}})
+		}
+		return nil
+	}
+
+	l.traceRoute(n[route])
+
+	switch n[route] {
+	case "blob", "tree", "src":
+	default:
+		return newParseError(ErrUnknownRoute, n[route])
+	}
+
+	// folder urls become file urls if filename is set, only the route keyword changes
+	if n[route] == "tree" && filename != "" {
+		n[route] = "blob"
+		l.RawPath = strings.Join(n, "/")
+	}
+
+	ref := n[route+1:]
+	if len(ref) == 0 {
+		if err := l.requireRef(); err != nil {
+			return err
+		}
+	}
+	if l.Branch != "" && strings.Contains(l.Branch, "/") {
+		// branch name contains slash, url must start with it
+		joined := strings.Join(ref, "/")
+		if joined != l.Branch && !strings.HasPrefix(joined, l.Branch+"/") {
+			segment, _, _ := strings.Cut(joined, "/")
+			if l.isExplaining() {
+				l.explain(ExplainStep{Rule: "branch split", Decision: "ambiguous", Evidence: "branch hint " + l.Branch + " does not start " + joined})
+			}
+			return newParseError(ErrAmbiguousRef, segment)
+		}
+		if branchSegments := strings.Count(l.Branch, "/") + 1; len(ref) > branchSegments {
+			l.Path = strings.Join(ref[branchSegments:], "/")
+		}
+		if l.isExplaining() {
+			l.explain(ExplainStep{Rule: "branch split", Decision: "branch " + l.Branch + ", path " + l.Path, Evidence: "branch hint with slashes starts the ref segments", Rejected: []string{"branch " + ref[0]}, Fields: []string{"branch", "path"}})
+		}
+	} else if len(ref) > 0 {
+		l.Branch = ref[0]
+		l.Path = strings.Join(ref[1:], "/")
+		if l.isExplaining() {
+			step := ExplainStep{Rule: "branch split", Decision: "branch " + l.Branch + ", path " + l.Path, Evidence: "first segment after route", Fields: []string{"branch", "path"}}
+			if len(ref) > 1 {
+				step.Rejected = []string{"branch names with slashes, set a branch hint"}
+			}
+			l.explain(step)
+		}
+	}
+
+	// Bug and TODO
+	// Bitbucket.org url has src not tree or blob.
+	// if url not slashes, after download system failed because IsFile value not correct
+	// r.IsFile = !strings.HasSuffix(r.Path, "/")
+	switch {
+	case l.Branch == "":
+		// route without ref is the repository root
+		l.IsFile = false
+	case n[route] == "tree":
+		l.IsFile = false
+	case n[route] == "blob":
+		l.IsFile = true
+	}
+	if l.isExplaining() && (n[route] != "src" || l.Branch == "") {
+		evidence := n[route] + " route"
+		if l.Branch == "" {
+			evidence += " without ref"
+		}
+		l.explain(ExplainStep{Rule: "route file", Decision: strconv.FormatBool(l.IsFile), Evidence: evidence, Fields: []string{"is_file"}})
+	}
+
+	return nil
+}
+
+// providers with positional routes rebuild url path from branch and path
+// location changes keep raw path of these providers consistent
+type routePathBuilder interface {
+	routePath(l *Location) string
+}
+
+// generate positional url path
+// /[OWNER]/[NAME]/[ROUTE]/[BRANCH]/[PATH], route is blob for files, tree for folders if empty
+func (l *Location) getPositionalPath(route string) string {
+	if l.Branch == "" {
+		return "/" + l.Owner + "/" + l.Name
+	}
+	if route == "" {
+		route = "tree"
+		if l.IsFile {
+			route = "blob"
+		}
+	}
+
+	return strings.TrimSuffix("/"+l.Owner+"/"+l.Name+"/"+route+"/"+l.Branch+"/"+l.Path, "/")
+}
+
+// route keyword of positional raw path, empty for repository root
+// /[OWNER]/[NAME]/[ROUTE]/... -> [ROUTE]
+func (l *Location) getPositionalRoute() string {
+	rest, ok := strings.CutPrefix(l.RawPath, "/"+l.Owner+"/"+l.Name+"/")
+	if !ok {
+		return ""
+	}
+	route, _, _ := strings.Cut(rest, "/")
+
+	return route
+}
+
+// escape url path segments, slashes stay
+// feature/a b -> feature/a%20b, 100% -> 100%25
+func escapePath(path string) string {
+	if !needsEscape(path) {
+		return path
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// escape query value of paths and refs, slashes stay
+// src/a b -> src/a+b, a;b -> a%3Bb
+func escapeQueryPath(path string) string {
+	if !needsEscape(path) {
+		return path
+	}
+
+	return strings.ReplaceAll(url.QueryEscape(path), "%2F", "/")
+}
+
+// url breaking characters: spaces, controls, non ascii, escapes, query, fragment and query separators
+// [PATH] placeholders stay
+func needsEscape(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c <= ' ' || c >= 0x7f || strings.IndexByte("%?#;&+\"", c) != -1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// route keyword without ref: default mode reads repository root, strict mode fails
+func (l *Location) requireRef() error {
+	if l.mode == modeStrict {
+		return newParseError(ErrInvalidRef, "")
+	}
+
+	return nil
+}
+
+// providers registry
+var (
+	providersMu sync.RWMutex
+
+	// registered providers first, they replace built-in providers of the same forge
+	// later registrations win over earlier ones, in match and in forge lookup
+	registeredProviders = []Provider{}
+
+	// self-hosted hostnames registered by users
+	forgeHosts = map[string]string{}
+)
+
+// built-in providers, matched in order
+// route based providers last: they match unknown hostnames
+var builtinProviders = []Provider{
+	githubProvider{},
+	gitlabProvider{},
+	bitbucketProvider{},
+	giteaProvider{forge: ForgeGitea, hostnames: []string{"gitea.com"}},
+	giteaProvider{forge: ForgeForgejo, hostnames: []string{"codeberg.org"}},
+	gogsProvider{},
+	giteeProvider{},
+	gitCodeProvider{},
+	pagureProvider{},
+	azureDevOpsProvider{},
+	sourceHutProvider{},
+	huggingFaceProvider{},
+	launchpadProvider{},
+	gitwebProvider{},
+	cgitProvider{},
+	gitilesProvider{},
+	codeCommitProvider{},
+	bitbucketServerProvider{},
+}
+
+// register provider of a git hosting software
+// registered providers match before built-in providers, the last registered provider first
+func RegisterProvider(provider Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	registeredProviders = append(registeredProviders, provider)
+}
+
+// register self-hosted hostname with its git hosting software
+// RegisterForge("git.corp", ForgeBitbucketServer)
+func RegisterForge(hostname, forge string) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	forgeHosts[strings.ToLower(hostname)] = forge
+}
+
+// find provider of url, generic provider for unknown hosts
+func findProvider(u *url.URL) Provider {
+	providersMu.RLock()
+	forge, ok := forgeHosts[strings.ToLower(u.Hostname())]
+	providersMu.RUnlock()
+	if ok {
+		// cgit and gitweb hosts serve both front-ends sometimes
+		if forge == ForgeCgit || forge == ForgeGitweb {
+			forge = findCgitOrGitweb(u)
+		}
+		return findProviderByForge(forge)
+	}
+
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	for i := len(registeredProviders) - 1; i >= 0; i-- {
+		if registeredProviders[i].Match(u) {
+			return registeredProviders[i]
+		}
+	}
+	for _, provider := range builtinProviders {
+		if provider.Match(u) {
+			return provider
+		}
+	}
+
+	return GenericProvider{}
+}
+
+// find provider of forge name, generic provider for unknown forges
+func findProviderByForge(forge string) Provider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	for i := len(registeredProviders) - 1; i >= 0; i-- {
+		if registeredProviders[i].Forge() == forge {
+			return registeredProviders[i]
+		}
+	}
+	for _, provider := range builtinProviders {
+		if provider.Forge() == forge {
+			return provider
+		}
+	}
+
+	return GenericProvider{}
+}
+
+// gitweb urls have project query, cgit urls have repository path
+func findCgitOrGitweb(u *url.URL) string {
+	if isGitwebUrl(u) {
+		return ForgeGitweb
+	}
+
+	return ForgeCgit
+}
+
+// repository name without .git suffix of clone urls, any case: .Git, .GIT
+func trimGitSuffix(name string) string {
+	if len(name) >= 4 && strings.EqualFold(name[len(name)-4:], ".git") {
+		return name[:len(name)-4]
+	}
+
+	return name
+}
+
+// owner and name segments of <owner>/<repo> routes
+func requireOwnerName(segments []string) error {
+	switch {
+	case len(segments) == 0 || segments[0] == "":
+		return newParseError(ErrMissingOwner, "")
+	case len(segments) < 2 || segments[1] == "":
+		return newParseError(ErrMissingRepo, "")
+	}
+
+	return nil
+}
+
+// hostname of url is one of hostnames
+func matchHostname(u *url.URL, hostnames ...string) bool {
+	hostname := strings.ToLower(u.Hostname())
+	for _, h := range hostnames {
+		if hostname == h {
+			return true
+		}
+	}
+
+	return false
+}
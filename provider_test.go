@@ -0,0 +1,115 @@
+package gitrepository
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_ProviderParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Unknown Host Generic Provider",
+			url:    "https://git.example.io/owner/repo/tree/main/docs",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.example.io/owner/repo/tree/main/docs",
+				RawUrl:       "https://git.example.io/owner/repo/tree/main/docs",
+				CloneUrl:     "https://git.example.io/owner/repo.git",
+				RemoteUrl:    "git@git.example.io:owner/repo.git",
+				QueryUrl:     "https://git.example.io/owner/repo",
+				DirPath:      keyDirPath(Key{Hostname: "git.example.io", Namespace: "owner", Name: "repo", RefKind: RefBranch, Ref: "main"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.example.io",
+				Forge:        "",
+				RawPath:      "/owner/repo/tree/main/docs",
+				Path:         "docs",
+				Owner:        "owner",
+				Name:         "repo",
+				DummyBranch:  "gitd-branch",
+				Branch:       "main",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "",
+				FileUrl:      "",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
+
+// provider of forge name with its own hostname
+type hostProvider struct {
+	GenericProvider
+	forge, hostname string
+	version         int
+}
+
+func (p hostProvider) Forge() string {
+	return p.forge
+}
+
+func (p hostProvider) Match(u *url.URL) bool {
+	return matchHostname(u, p.hostname)
+}
+
+func TestRegisterProvider_Precedence(t *testing.T) {
+	restoreRegistry(t)
+	first := hostProvider{forge: "phorge", hostname: "git.example.com", version: 1}
+	last := hostProvider{forge: "phorge", hostname: "git.example.com", version: 2}
+	RegisterProvider(first)
+	RegisterProvider(last)
+
+	// parse and build use the same provider: the last registered one
+	u, _ := url.Parse("https://git.example.com/owner/repo")
+	if got := findProvider(u); !reflect.DeepEqual(got, last) {
+		t.Errorf("findProvider() = %#v, want %#v", got, last)
+	}
+	if got := findProviderByForge("phorge"); !reflect.DeepEqual(got, last) {
+		t.Errorf("findProviderByForge() = %#v, want %#v", got, last)
+	}
+}
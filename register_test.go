@@ -0,0 +1,214 @@
+package gitrepository_test
+
+import (
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	gitrepository "github.com/git-download-manager/git-url-parse"
+)
+
+// phabricator diffusion provider, third-party provider example
+// only exported api of the package, like providers of other modules
+// https://<hostname>/source/<repo>/browse/<branch>/<path>
+type diffusionProvider struct {
+	gitrepository.GenericProvider
+}
+
+func (diffusionProvider) Forge() string {
+	return "diffusion"
+}
+
+func (diffusionProvider) Match(u *url.URL) bool {
+	return strings.EqualFold(u.Hostname(), "phabricator.example.com")
+}
+
+func (diffusionProvider) ParseRoute(l *gitrepository.Location, u *url.URL, filename string) error {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case segments[0] != "source":
+		return &gitrepository.ParseError{Segment: segments[0], Err: gitrepository.ErrUnknownRoute}
+	case len(segments) < 2 || segments[1] == "":
+		return &gitrepository.ParseError{Err: gitrepository.ErrMissingRepo}
+	}
+
+	l.Name = strings.TrimSuffix(segments[1], ".git")
+	l.RawPath = "/source/" + l.Name
+	if len(segments) > 2 {
+		if segments[2] != "browse" {
+			return &gitrepository.ParseError{Segment: segments[2], Err: gitrepository.ErrUnknownRoute}
+		}
+		if len(segments) < 4 {
+			return &gitrepository.ParseError{Err: gitrepository.ErrInvalidRef}
+		}
+		l.Branch = segments[3]
+		l.Path = strings.Join(segments[4:], "/")
+	}
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+	l.IsFile = filename != "" || (l.Path != "" && !strings.HasSuffix(u.Path, "/"))
+
+	return nil
+}
+
+func (diffusionProvider) BaseUrl(l *gitrepository.Location) string {
+	return l.Scheme + "://" + l.Hostname + l.RawPath
+}
+
+func (p diffusionProvider) BrowseUrl(l *gitrepository.Location) string {
+	if l.Branch == "" {
+		return p.BaseUrl(l)
+	}
+	return strings.TrimSuffix(p.BaseUrl(l)+"/browse/"+filepath.Join(l.Branch, l.Path), "/")
+}
+
+func (p diffusionProvider) CloneUrl(l *gitrepository.Location) string {
+	return p.BaseUrl(l) + ".git"
+}
+
+func (diffusionProvider) RemoteUrl(l *gitrepository.Location) string {
+	return "ssh://git@" + l.Hostname + l.RawPath + ".git"
+}
+
+func (p diffusionProvider) FileUrl(l *gitrepository.Location, path string) string {
+	return p.BaseUrl(l) + "/browse/" + l.Branch + "/" + path + "?view=raw"
+}
+
+func (p diffusionProvider) QueryUrl(l *gitrepository.Location, path string) string {
+	return p.BaseUrl(l) + "/browse/" + filepath.Join(l.Branch, path) + "/"
+}
+
+// dir path of key without temp dir and session
+func keyDirPath(key gitrepository.Key) string {
+	return filepath.Join("repository", key.Hash())
+}
+
+func TestRegisterProvider(t *testing.T) {
+	gitrepository.RestoreRegistry(t)
+	gitrepository.RegisterProvider(diffusionProvider{})
+
+	tests := []struct {
+		name    string
+		url     string
+		branch  string
+		sub     string
+		wantObj *gitrepository.GitRepository
+		wantErr bool
+	}{
+		{
+			name:   "Parse Registered Provider Repository",
+			url:    "https://phabricator.example.com/source/arcanist",
+			branch: "",
+			sub:    "",
+			wantObj: &gitrepository.GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://phabricator.example.com/source/arcanist",
+				RawUrl:       "https://phabricator.example.com/source/arcanist",
+				CloneUrl:     "https://phabricator.example.com/source/arcanist.git",
+				RemoteUrl:    "ssh://git@phabricator.example.com/source/arcanist.git",
+				QueryUrl:     "https://phabricator.example.com/source/arcanist",
+				DirPath:      keyDirPath(gitrepository.Key{Hostname: "phabricator.example.com", Name: "arcanist", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "phabricator.example.com",
+				Forge:        "diffusion",
+				RawPath:      "/source/arcanist",
+				Path:         "",
+				Owner:        "",
+				Name:         "arcanist",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "",
+				FileUrl:      "https://phabricator.example.com/source/arcanist/browse//[PATH]?view=raw",
+				DownloadType: gitrepository.DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Registered Provider Single File",
+			url:    "https://phabricator.example.com/source/arcanist/browse/master/src/init.php",
+			branch: "",
+			sub:    "",
+			wantObj: &gitrepository.GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://phabricator.example.com/source/arcanist/browse/master/src/init.php",
+				RawUrl:       "https://phabricator.example.com/source/arcanist/browse/master/src/init.php",
+				CloneUrl:     "https://phabricator.example.com/source/arcanist.git",
+				RemoteUrl:    "ssh://git@phabricator.example.com/source/arcanist.git",
+				QueryUrl:     "https://phabricator.example.com/source/arcanist/browse/master/src/",
+				DirPath:      keyDirPath(gitrepository.Key{Hostname: "phabricator.example.com", Name: "arcanist", RefKind: gitrepository.RefBranch, Ref: "master"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "phabricator.example.com",
+				Forge:        "diffusion",
+				RawPath:      "/source/arcanist",
+				Path:         "src/init.php",
+				Owner:        "",
+				Name:         "arcanist",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      gitrepository.RefBranch,
+				ArchiveUrl:   "",
+				FileUrl:      "https://phabricator.example.com/source/arcanist/browse/master/[PATH]?view=raw",
+				DownloadType: gitrepository.DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse Registered Provider Unknown Route",
+			url:    "https://phabricator.example.com/source/arcanist/history/master",
+			branch: "",
+			sub:    "",
+			wantObj: &gitrepository.GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				RawUrl:      "https://phabricator.example.com/source/arcanist/history/master",
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "phabricator.example.com",
+				Forge:       "diffusion",
+				RawPath:     "/source/arcanist",
+				Name:        "arcanist",
+				DummyBranch: "gitd-branch",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &gitrepository.GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, gitrepository.DirectionNone, ""); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package gitrepository
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// sourcehut provider, git.sr.ht
+type sourceHutProvider struct {
+	GenericProvider
+}
+
+func (sourceHutProvider) Forge() string {
+	return ForgeSourceHut
+}
+
+func (sourceHutProvider) Match(u *url.URL) bool {
+	return matchHostname(u, "git.sr.ht")
+}
+
+func (sourceHutProvider) ParseRoute(l *Location, u *url.URL, filename string) error {
+	return l.parseSourceHutRoute(u, filename)
+}
+
+// path lives after item segment
+func (sourceHutProvider) BrowseUrl(l *Location) string {
+	return l.getSourceHutTreeUrl(l.Path)
+}
+
+// https://git.sr.ht/[OWNER]/[NAME]
+func (sourceHutProvider) CloneUrl(l *Location) string {
+	return l.getBaseUrl()
+}
+
+// git@git.sr.ht:[OWNER]/[NAME]
+func (sourceHutProvider) RemoteUrl(l *Location) string {
+	return "git@" + l.Hostname + ":" + l.Owner + "/" + l.Name
+}
+
+// https://git.sr.ht/[OWNER]/[NAME]/archive/[BRANCH].tar.gz
+func (sourceHutProvider) ArchiveUrl(l *Location) string {
+	return l.getBaseUrl() + "/archive/" + l.Branch + ".tar.gz"
+}
+
+// https://git.sr.ht/[OWNER]/[NAME]/blob/[BRANCH]/[PATH]
+// https://git.sr.ht/~sircmpwn/scdoc/blob/master/scdoc.1.scd
+func (sourceHutProvider) FileUrl(l *Location, path string) string {
+	return l.getBaseUrl() + "/blob/" + l.Branch + "/" + path
+}
+
+// https://git.sr.ht/[OWNER]/[NAME]/tree/[BRANCH]/item/[PATH]/
+func (sourceHutProvider) QueryUrl(l *Location, path string) string {
+	return l.getSourceHutTreeUrl(path) + "/"
+}
+
+// parse sourcehut routes, owners start with ~
+/*
+https://git.sr.ht/~<owner>/<repo>
+https://git.sr.ht/~<owner>/<repo>/tree/<branch> -> root folder
+https://git.sr.ht/~<owner>/<repo>/tree/<branch>/item/<path> -> item splits branch and path
+https://git.sr.ht/~<owner>/<repo>/blob/<branch>/<path> -> raw single file
+https://git.sr.ht/~<owner>/<repo>/archive/<branch>.tar.gz -> root folder
+ssh://git@git.sr.ht/~<owner>/<repo>
+*/
+func (l *Location) parseSourceHutRoute(u *url.URL, filename string) error {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case !strings.HasPrefix(segments[0], "~") || len(segments[0]) == 1:
+		return newParseError(ErrMissingOwner, segments[0])
+	case len(segments) < 2 || segments[1] == "":
+		return newParseError(ErrMissingRepo, "")
+	}
+
+	l.Owner = segments[0]
+	l.Name = trimGitSuffix(segments[1])
+	if l.Name == "" {
+		return newParseError(ErrMissingRepo, "")
+	}
+	l.RawPath = "/" + l.Owner + "/" + l.Name
+
+	route, rest := "", segments[2:]
+	if len(rest) > 0 {
+		route, rest = rest[0], rest[1:]
+	}
+
+	l.traceRoute(route)
+	switch route {
+	case "":
+	case "tree":
+		// <branch>/item/<path>, branch names with slashes end before item
+		ref := rest
+		for i, segment := range rest {
+			if segment == "item" {
+				ref, l.Path = rest[:i], strings.Join(rest[i+1:], "/")
+				break
+			}
+		}
+		if len(ref) > 0 {
+			l.Branch = strings.Join(ref, "/")
+		} else if err := l.requireRef(); err != nil {
+			return err
+		}
+	case "blob":
+		// <branch>/<path>, user set branch name first
+		if len(rest) == 0 {
+			return newParseError(ErrInvalidRef, "")
+		}
+		joined := strings.Join(rest, "/")
+		if l.Branch == "" || (joined != l.Branch && !strings.HasPrefix(joined, l.Branch+"/")) {
+			l.Branch = rest[0]
+		}
+		l.Path = strings.TrimPrefix(strings.TrimPrefix(joined, l.Branch), "/")
+	case "archive":
+		// <branch>.tar.gz, branch names with slashes
+		ref := strings.Join(rest, "/")
+		branch, ok := strings.CutSuffix(ref, ".tar.gz")
+		if !ok || branch == "" {
+			return newParseError(ErrInvalidRef, ref)
+		}
+		l.Branch = branch
+	default:
+		return newParseError(ErrUnknownRoute, route)
+	}
+	l.Path = strings.Trim(filepath.Join(l.Path, filename), "/")
+
+	// blob route only serves files, tree urls do not tell file or folder
+	l.IsFile = filename != "" || (route == "blob" && l.Path != "") || (l.Path != "" && !strings.HasSuffix(u.Path, "/") && filepath.Ext(l.Path) != "")
+
+	return nil
+}
+
+// generate sourcehut web url
+// https://git.sr.ht/[OWNER]/[NAME]/tree/[BRANCH]/item/[PATH]
+func (l *Location) getSourceHutTreeUrl(path string) string {
+	if l.Branch == "" {
+		return l.getBaseUrl()
+	}
+
+	treeUrl := l.getBaseUrl() + "/tree/" + escapePath(l.Branch)
+	if path != "" {
+		treeUrl += "/item/" + escapePath(path)
+	}
+
+	return treeUrl
+}
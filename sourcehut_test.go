@@ -0,0 +1,372 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitRepository_SourceHutParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		branch   string
+		sub      string
+		filename string
+		wantObj  *GitRepository
+		wantErr  bool
+	}{
+		{
+			name:   "Parse SourceHut Repository",
+			url:    "https://git.sr.ht/~sircmpwn/scdoc",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.sr.ht/~sircmpwn/scdoc",
+				RawUrl:       "https://git.sr.ht/~sircmpwn/scdoc",
+				CloneUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				RemoteUrl:    "git@git.sr.ht:~sircmpwn/scdoc",
+				QueryUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				DirPath:      keyDirPath(Key{Hostname: "git.sr.ht", Namespace: "~sircmpwn", Name: "scdoc", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.sr.ht",
+				Forge:        ForgeSourceHut,
+				RawPath:      "/~sircmpwn/scdoc",
+				Path:         "",
+				Owner:        "~sircmpwn",
+				Name:         "scdoc",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.sr.ht/~sircmpwn/scdoc/archive/.tar.gz",
+				FileUrl:      "https://git.sr.ht/~sircmpwn/scdoc/blob//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse SourceHut Root Directory Single File",
+			url:    "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/scdoc.1.scd",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/scdoc.1.scd",
+				RawUrl:       "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/scdoc.1.scd",
+				CloneUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				RemoteUrl:    "git@git.sr.ht:~sircmpwn/scdoc",
+				QueryUrl:     "https://git.sr.ht/~sircmpwn/scdoc/tree/master/",
+				DirPath:      keyDirPath(Key{Hostname: "git.sr.ht", Namespace: "~sircmpwn", Name: "scdoc", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.sr.ht",
+				Forge:        ForgeSourceHut,
+				RawPath:      "/~sircmpwn/scdoc",
+				Path:         "scdoc.1.scd",
+				Owner:        "~sircmpwn",
+				Name:         "scdoc",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.sr.ht/~sircmpwn/scdoc/archive/master.tar.gz",
+				FileUrl:      "https://git.sr.ht/~sircmpwn/scdoc/blob/master/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse SourceHut Slashes Branch Name Folder",
+			url:    "https://git.sr.ht/~sircmpwn/scdoc/tree/feature/x/item/include",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.sr.ht/~sircmpwn/scdoc/tree/feature/x/item/include",
+				RawUrl:       "https://git.sr.ht/~sircmpwn/scdoc/tree/feature/x/item/include",
+				CloneUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				RemoteUrl:    "git@git.sr.ht:~sircmpwn/scdoc",
+				QueryUrl:     "https://git.sr.ht/~sircmpwn/scdoc/tree/feature/x/item/include/",
+				DirPath:      keyDirPath(Key{Hostname: "git.sr.ht", Namespace: "~sircmpwn", Name: "scdoc", RefKind: RefBranch, Ref: "feature/x"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.sr.ht",
+				Forge:        ForgeSourceHut,
+				RawPath:      "/~sircmpwn/scdoc",
+				Path:         "include",
+				Owner:        "~sircmpwn",
+				Name:         "scdoc",
+				DummyBranch:  "gitd-branch",
+				Branch:       "feature/x",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.sr.ht/~sircmpwn/scdoc/archive/feature/x.tar.gz",
+				FileUrl:      "https://git.sr.ht/~sircmpwn/scdoc/blob/feature/x/[PATH]",
+				DownloadType: DownloadPartialPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse SourceHut Different Branch Repository",
+			url:    "https://git.sr.ht/~sircmpwn/scdoc/tree/devel",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.sr.ht/~sircmpwn/scdoc/tree/devel",
+				RawUrl:       "https://git.sr.ht/~sircmpwn/scdoc/tree/devel",
+				CloneUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				RemoteUrl:    "git@git.sr.ht:~sircmpwn/scdoc",
+				QueryUrl:     "https://git.sr.ht/~sircmpwn/scdoc/tree/devel/",
+				DirPath:      keyDirPath(Key{Hostname: "git.sr.ht", Namespace: "~sircmpwn", Name: "scdoc", RefKind: RefBranch, Ref: "devel"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.sr.ht",
+				Forge:        ForgeSourceHut,
+				RawPath:      "/~sircmpwn/scdoc",
+				Path:         "",
+				Owner:        "~sircmpwn",
+				Name:         "scdoc",
+				DummyBranch:  "gitd-branch",
+				Branch:       "devel",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.sr.ht/~sircmpwn/scdoc/archive/devel.tar.gz",
+				FileUrl:      "https://git.sr.ht/~sircmpwn/scdoc/blob/devel/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse SourceHut Blob Single File",
+			url:    "https://git.sr.ht/~sircmpwn/scdoc/blob/master/src/main.c",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/src/main.c",
+				RawUrl:       "https://git.sr.ht/~sircmpwn/scdoc/blob/master/src/main.c",
+				CloneUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				RemoteUrl:    "git@git.sr.ht:~sircmpwn/scdoc",
+				QueryUrl:     "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/src/",
+				DirPath:      keyDirPath(Key{Hostname: "git.sr.ht", Namespace: "~sircmpwn", Name: "scdoc", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.sr.ht",
+				Forge:        ForgeSourceHut,
+				RawPath:      "/~sircmpwn/scdoc",
+				Path:         "src/main.c",
+				Owner:        "~sircmpwn",
+				Name:         "scdoc",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.sr.ht/~sircmpwn/scdoc/archive/master.tar.gz",
+				FileUrl:      "https://git.sr.ht/~sircmpwn/scdoc/blob/master/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Parse SourceHut Item Folder Filename",
+			url:      "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/src",
+			branch:   "",
+			sub:      "",
+			filename: "main.c",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/src/main.c",
+				RawUrl:       "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/src",
+				CloneUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				RemoteUrl:    "git@git.sr.ht:~sircmpwn/scdoc",
+				QueryUrl:     "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/src/",
+				DirPath:      keyDirPath(Key{Hostname: "git.sr.ht", Namespace: "~sircmpwn", Name: "scdoc", RefKind: RefBranch, Ref: "master"}),
+				IsFile:       true,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.sr.ht",
+				Forge:        ForgeSourceHut,
+				RawPath:      "/~sircmpwn/scdoc",
+				Path:         "src/main.c",
+				Owner:        "~sircmpwn",
+				Name:         "scdoc",
+				DummyBranch:  "gitd-branch",
+				Branch:       "master",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.sr.ht/~sircmpwn/scdoc/archive/master.tar.gz",
+				FileUrl:      "https://git.sr.ht/~sircmpwn/scdoc/blob/master/[PATH]",
+				DownloadType: DownloadSingleFile,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse SourceHut Archive Url",
+			url:    "https://git.sr.ht/~sircmpwn/scdoc/archive/1.11.3.tar.gz",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.sr.ht/~sircmpwn/scdoc/tree/1.11.3",
+				RawUrl:       "https://git.sr.ht/~sircmpwn/scdoc/archive/1.11.3.tar.gz",
+				CloneUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				RemoteUrl:    "git@git.sr.ht:~sircmpwn/scdoc",
+				QueryUrl:     "https://git.sr.ht/~sircmpwn/scdoc/tree/1.11.3/",
+				DirPath:      keyDirPath(Key{Hostname: "git.sr.ht", Namespace: "~sircmpwn", Name: "scdoc", RefKind: RefBranch, Ref: "1.11.3"}),
+				IsFile:       false,
+				Protocol:     "https",
+				Scheme:       "https",
+				Hostname:     "git.sr.ht",
+				Forge:        ForgeSourceHut,
+				RawPath:      "/~sircmpwn/scdoc",
+				Path:         "",
+				Owner:        "~sircmpwn",
+				Name:         "scdoc",
+				DummyBranch:  "gitd-branch",
+				Branch:       "1.11.3",
+				RefKind:      RefBranch,
+				ArchiveUrl:   "https://git.sr.ht/~sircmpwn/scdoc/archive/1.11.3.tar.gz",
+				FileUrl:      "https://git.sr.ht/~sircmpwn/scdoc/blob/1.11.3/[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse SourceHut Archive Url Without Extension",
+			url:    "https://git.sr.ht/~sircmpwn/scdoc/archive/master",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				RawUrl:      "https://git.sr.ht/~sircmpwn/scdoc/archive/master",
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "git.sr.ht",
+				Forge:       ForgeSourceHut,
+				RawPath:     "/~sircmpwn/scdoc",
+				Owner:       "~sircmpwn",
+				Name:        "scdoc",
+				DummyBranch: "gitd-branch",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Parse SourceHut SSH Repository",
+			url:    "git@git.sr.ht:~sircmpwn/scdoc",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:      "",
+				SSID:         "",
+				Url:          "https://git.sr.ht/~sircmpwn/scdoc",
+				RawUrl:       "git@git.sr.ht:~sircmpwn/scdoc",
+				CloneUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				RemoteUrl:    "git@git.sr.ht:~sircmpwn/scdoc",
+				QueryUrl:     "https://git.sr.ht/~sircmpwn/scdoc",
+				DirPath:      keyDirPath(Key{Hostname: "git.sr.ht", Namespace: "~sircmpwn", Name: "scdoc", Ref: "gitd-branch"}),
+				IsFile:       false,
+				Protocol:     "ssh",
+				Scheme:       "https",
+				Hostname:     "git.sr.ht",
+				Forge:        ForgeSourceHut,
+				RawPath:      "/~sircmpwn/scdoc",
+				Path:         "",
+				Owner:        "~sircmpwn",
+				Name:         "scdoc",
+				DummyBranch:  "gitd-branch",
+				Branch:       "",
+				ArchiveUrl:   "https://git.sr.ht/~sircmpwn/scdoc/archive/.tar.gz",
+				FileUrl:      "https://git.sr.ht/~sircmpwn/scdoc/blob//[PATH]",
+				DownloadType: DownloadFullPackage,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Parse SourceHut Missing Owner Convention",
+			url:    "https://git.sr.ht/sircmpwn/scdoc",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://git.sr.ht/sircmpwn/scdoc",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "git.sr.ht",
+				Forge:       ForgeSourceHut,
+				Path:        "",
+				Owner:       "",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Parse SourceHut Not Valid Route",
+			url:    "https://git.sr.ht/~sircmpwn/scdoc/log",
+			branch: "",
+			sub:    "",
+			wantObj: &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      "https://git.sr.ht/~sircmpwn/scdoc/log",
+				IsFile:      false,
+				Protocol:    "https",
+				Scheme:      "https",
+				Hostname:    "git.sr.ht",
+				Forge:       ForgeSourceHut,
+				RawPath:     "/~sircmpwn/scdoc",
+				Path:        "",
+				Owner:       "~sircmpwn",
+				Name:        "scdoc",
+				DummyBranch: "gitd-branch",
+				Branch:      "",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GitRepository{
+				TempDir:     "",
+				SSID:        "",
+				Url:         "",
+				RawUrl:      tt.url,
+				CloneUrl:    "",
+				RemoteUrl:   "",
+				DirPath:     "",
+				IsFile:      false,
+				Protocol:    "",
+				Scheme:      "",
+				Hostname:    "",
+				RawPath:     "",
+				Path:        "",
+				Owner:       "",
+				Name:        "",
+				DummyBranch: "gitd-branch",
+				Branch:      tt.branch,
+				ArchiveUrl:  "",
+				FileUrl:     "",
+			}
+			if err := r.Parse(tt.sub, DirectionNone, tt.filename); (err != nil) != tt.wantErr {
+				t.Errorf("GitRepository.Parse() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(r, tt.wantObj) {
+				t.Errorf("DeepEqual r = %#v, wantObj %#v", r, tt.wantObj)
+			}
+		})
+	}
+}
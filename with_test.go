@@ -0,0 +1,168 @@
+package gitrepository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocation_With(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		with    func(l Location) Location
+		wantUrl string // parse of this url is the same location
+	}{
+		{
+			name:    "Github With Branch",
+			url:     "https://github.com/cli/cli/tree/trunk/pkg",
+			with:    func(l Location) Location { return l.WithBranch("v2") },
+			wantUrl: "https://github.com/cli/cli/tree/v2/pkg",
+		},
+		{
+			name:    "Github Root With Branch",
+			url:     "https://github.com/cli/cli",
+			with:    func(l Location) Location { return l.WithBranch("trunk") },
+			wantUrl: "https://github.com/cli/cli/tree/trunk",
+		},
+		{
+			name:    "Github With File",
+			url:     "https://github.com/cli/cli/tree/trunk",
+			with:    func(l Location) Location { return l.WithFile("go.mod") },
+			wantUrl: "https://github.com/cli/cli/blob/trunk/go.mod",
+		},
+		{
+			name:    "Github File With Path",
+			url:     "https://github.com/cli/cli/blob/trunk/pkg/cmd/root.go",
+			with:    func(l Location) Location { return l.WithPath("pkg/cmd") },
+			wantUrl: "https://github.com/cli/cli/tree/trunk/pkg/cmd",
+		},
+		{
+			name:    "Gitlab Subgroups With Path",
+			url:     "https://gitlab.com/gitlab-org/api/client-go/-/tree/main/examples",
+			with:    func(l Location) Location { return l.WithPath("testdata") },
+			wantUrl: "https://gitlab.com/gitlab-org/api/client-go/-/tree/main/testdata",
+		},
+		{
+			name:    "Bitbucket With Branch",
+			url:     "https://bitbucket.org/tiagoharris/url-shortener/src/master/cmd/",
+			with:    func(l Location) Location { return l.WithBranch("develop") },
+			wantUrl: "https://bitbucket.org/tiagoharris/url-shortener/src/develop/cmd/",
+		},
+		{
+			name:    "Gitea With Tag",
+			url:     "https://gitea.com/gitea/tea/src/branch/main/cmd/",
+			with:    func(l Location) Location { return l.WithRef(RefTag, "v0.9.2") },
+			wantUrl: "https://gitea.com/gitea/tea/src/tag/v0.9.2/cmd/",
+		},
+		{
+			name:    "Gitea Tag With Full Ref Branch",
+			url:     "https://gitea.com/gitea/tea/src/tag/v0.9.2/cmd/",
+			with:    func(l Location) Location { return l.WithRef("", "refs/heads/main") },
+			wantUrl: "https://gitea.com/gitea/tea/src/branch/main/cmd/",
+		},
+		{
+			name:    "Sourcehut With Branch",
+			url:     "https://git.sr.ht/~sircmpwn/scdoc/tree/master/item/include",
+			with:    func(l Location) Location { return l.WithBranch("devel") },
+			wantUrl: "https://git.sr.ht/~sircmpwn/scdoc/tree/devel/item/include",
+		},
+		{
+			name:    "Hugging Face With File",
+			url:     "https://huggingface.co/openai-community/gpt2/tree/main",
+			with:    func(l Location) Location { return l.WithFile("config.json") },
+			wantUrl: "https://huggingface.co/openai-community/gpt2/blob/main/config.json",
+		},
+		{
+			name:    "Unknown Host With Branch And File",
+			url:     "https://git.example.io/owner/repo/tree/main/docs",
+			with:    func(l Location) Location { return l.WithBranch("dev").WithFile("x/y.txt") },
+			wantUrl: "https://git.example.io/owner/repo/blob/dev/x/y.txt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := ParseURL(tt.url)
+			if err != nil {
+				t.Fatalf("ParseURL(%q) error = %v", tt.url, err)
+			}
+			before := l
+
+			want, err := ParseURL(tt.wantUrl)
+			if err != nil {
+				t.Fatalf("ParseURL(%q) error = %v", tt.wantUrl, err)
+			}
+			want.RawUrl = tt.url
+
+			got := tt.with(l)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("DeepEqual got = %#v, want %#v", got, want)
+			}
+			if l != before {
+				t.Errorf("With changed location = %#v, want %#v", l, before)
+			}
+		})
+	}
+}
+
+func TestGitRepository_UpdateBranch(t *testing.T) {
+	r := NewGitRepository("", "", "https://codeberg.org/forgejo/forgejo/src/branch/forgejo/docs/", "")
+	if err := r.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+
+	want := NewGitRepository("", "", "https://codeberg.org/forgejo/forgejo/src/tag/v1.21.0/docs/", "")
+	if err := want.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+	want.RawUrl = r.RawUrl
+
+	tagged := r.WithRef(RefTag, "v1.21.0")
+	if !reflect.DeepEqual(tagged, want) {
+		t.Errorf("GitRepository.WithRef() = %#v, want %#v", tagged, want)
+	}
+	if r.Branch != "forgejo" || r.IsTagBranch {
+		t.Errorf("GitRepository.WithRef() changed repository = %#v", r)
+	}
+
+	// update branch regenerates url, query url, dir path and tag flag
+	r.UpdateBranch("v1.21.0")
+	want = want.WithBranch("v1.21.0")
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("GitRepository.UpdateBranch() = %#v, want %#v", r, want)
+	}
+	if r.Url != "https://codeberg.org/forgejo/forgejo/src/branch/v1.21.0/docs" || r.DirPath != keyDirPath(Key{Hostname: "codeberg.org", Namespace: "forgejo", Name: "forgejo", RefKind: RefBranch, Ref: "v1.21.0"}) || r.IsTagBranch {
+		t.Errorf("GitRepository.UpdateBranch() = %#v", r)
+	}
+}
+
+func TestLocation_WithSameBranch(t *testing.T) {
+	for _, raw := range []string{
+		"https://git.example.io/owner/repo/src/branch/main/cmd",
+		"https://git.example.io/owner/repo/src/develop/cmd",
+		"https://git.example.io/owner/repo/src/develop/cmd/",
+		"https://git.example.io/owner/repo/tree/main/docs",
+		"https://git.example.io/owner/repo/blob/main/docs/x.md",
+	} {
+		l, err := ParseURL(raw)
+		if err != nil {
+			t.Fatalf("ParseURL(%q) error = %v", raw, err)
+		}
+
+		// same branch, same location
+		if got := l.WithBranch(l.Branch); !reflect.DeepEqual(got, l) {
+			t.Errorf("WithBranch() of %q = %#v, want %#v", raw, got, l)
+		}
+	}
+}
+
+func TestGitRepository_UpdateBranchUnknownHost(t *testing.T) {
+	r := NewGitRepository("", "", "https://git.example.io/owner/repo/tree/main/docs", "")
+	if err := r.Parse("", DirectionNone, ""); err != nil {
+		t.Fatalf("GitRepository.Parse() error = %v", err)
+	}
+
+	r.UpdateBranch("dev")
+	if r.Url != "https://git.example.io/owner/repo/tree/dev/docs" || r.RawPath != "/owner/repo/tree/dev/docs" {
+		t.Errorf("GitRepository.UpdateBranch() = %#v", r)
+	}
+}